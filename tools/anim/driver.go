@@ -0,0 +1,182 @@
+// driver.go
+package anim
+
+import (
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// FrameFunc 每帧的回调，elapsed 为动画开始后经过的时间，返回 false 表示动画结束
+type FrameFunc func(elapsed time.Duration) bool
+
+// Driver 逐帧驱动动画
+// 控件只通过 Driver 接收帧，不直接使用 fyne.Animation 或 time.Ticker，
+// 测试中换成 ManualDriver 就能精确推进到任意一帧
+type Driver interface {
+	// Start 开始一段动画，返回的 stop 可以提前结束它，重复调用无副作用
+	Start(frame FrameFunc) (stop func())
+}
+
+// FyneDriver 用 fyne.Animation 在渲染线程上驱动，帧率与屏幕刷新同步
+type FyneDriver struct {
+	// Clock 计算 elapsed 使用的时钟，nil 时使用 SystemClock
+	Clock Clock
+}
+
+// DefaultDriver 控件未指定 Driver 时使用
+var DefaultDriver Driver = FyneDriver{}
+
+// Start 实现 Driver
+func (d FyneDriver) Start(frame FrameFunc) func() {
+	clock := d.Clock
+	if clock == nil {
+		clock = SystemClock
+	}
+	start := clock.Now()
+
+	var a *fyne.Animation
+	var once sync.Once
+	stop := func() { once.Do(a.Stop) }
+	// 时长只决定 fyne 回调中的进度值，这里不使用，以 elapsed 为准
+	a = fyne.NewAnimation(time.Second, func(float32) {
+		if !frame(clock.Now().Sub(start)) {
+			stop()
+		}
+	})
+	a.Curve = fyne.AnimationLinear
+	a.RepeatCount = fyne.AnimationRepeatForever
+	a.Start()
+	return stop
+}
+
+// DefaultFrameInterval ManualDriver 默认的帧间隔，约 60fps
+const DefaultFrameInterval = 16 * time.Millisecond
+
+// manualAnim ManualDriver 中进行中的一段动画
+type manualAnim struct {
+	start   time.Time
+	frame   FrameFunc
+	stopped bool
+}
+
+// ManualDriver 手动推进的 Driver，用于确定性的逐帧测试和金标准截图
+// 与 Timeline 共用同一个 ManualClock 时，两者看到的时间完全一致
+type ManualDriver struct {
+	// Interval 每帧的时长，0 表示 DefaultFrameInterval
+	Interval time.Duration
+
+	clock  *ManualClock
+	mu     sync.Mutex
+	active []*manualAnim
+}
+
+// NewManualDriver 创建手动驱动，clock 为 nil 时新建一个从零时刻开始的时钟
+func NewManualDriver(clock *ManualClock) *ManualDriver {
+	if clock == nil {
+		clock = NewManualClock(time.Time{})
+	}
+	return &ManualDriver{clock: clock}
+}
+
+// Clock 返回驱动使用的时钟，可以传给 NewTimeline
+func (d *ManualDriver) Clock() *ManualClock { return d.clock }
+
+// Start 实现 Driver，动画从当前时刻开始，第一帧在下一次 Step 时回调
+func (d *ManualDriver) Start(frame FrameFunc) func() {
+	a := &manualAnim{start: d.clock.Now(), frame: frame}
+	d.mu.Lock()
+	d.active = append(d.active, a)
+	d.mu.Unlock()
+	return func() {
+		d.mu.Lock()
+		a.stopped = true
+		d.mu.Unlock()
+	}
+}
+
+// Step 推进一帧：时钟前进 Interval，然后回调所有进行中的动画
+func (d *ManualDriver) Step() {
+	interval := d.Interval
+	if interval <= 0 {
+		interval = DefaultFrameInterval
+	}
+	d.step(interval)
+}
+
+// Advance 按帧推进 dur，最后不足一帧的部分作为一个短帧
+func (d *ManualDriver) Advance(dur time.Duration) {
+	interval := d.Interval
+	if interval <= 0 {
+		interval = DefaultFrameInterval
+	}
+	for dur > 0 {
+		h := min(dur, interval)
+		d.step(h)
+		dur -= h
+	}
+}
+
+// Settle 逐帧推进直到所有动画结束，最多推进 limit，返回是否全部结束
+func (d *ManualDriver) Settle(limit time.Duration) bool {
+	interval := d.Interval
+	if interval <= 0 {
+		interval = DefaultFrameInterval
+	}
+	for elapsed := time.Duration(0); d.Active() > 0; elapsed += interval {
+		if elapsed >= limit {
+			return false
+		}
+		d.step(interval)
+	}
+	return true
+}
+
+// Active 进行中的动画数量
+func (d *ManualDriver) Active() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := 0
+	for _, a := range d.active {
+		if !a.stopped {
+			n++
+		}
+	}
+	return n
+}
+
+func (d *ManualDriver) step(h time.Duration) {
+	d.clock.Advance(h)
+	now := d.clock.Now()
+
+	// 回调中可能再调用 Start 或 stop，所以在锁外执行
+	d.mu.Lock()
+	running := append([]*manualAnim(nil), d.active...)
+	d.mu.Unlock()
+
+	for _, a := range running {
+		d.mu.Lock()
+		stopped := a.stopped
+		d.mu.Unlock()
+		if stopped {
+			continue
+		}
+		if !a.frame(now.Sub(a.start)) {
+			d.mu.Lock()
+			a.stopped = true
+			d.mu.Unlock()
+		}
+	}
+
+	d.mu.Lock()
+	kept := d.active[:0]
+	for _, a := range d.active {
+		if !a.stopped {
+			kept = append(kept, a)
+		}
+	}
+	clear(d.active[len(kept):])
+	d.active = kept
+	d.mu.Unlock()
+}
@@ -0,0 +1,160 @@
+package anim
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	_ "fyne.io/fyne/v2/test" // 安装测试 App，FyneDriver 需要它
+
+	"2025-12-18-ggAndPng/tools/golden"
+	"2025-12-18-ggAndPng/tools/vector"
+)
+
+func TestManualDriverStep(t *testing.T) {
+	d := NewManualDriver(nil)
+	var frames []time.Duration
+	d.Start(func(elapsed time.Duration) bool {
+		frames = append(frames, elapsed)
+		return elapsed < 40*time.Millisecond
+	})
+
+	d.Step()
+	d.Advance(40 * time.Millisecond) // 16 + 16 + 8
+	// 第三帧返回 false 后动画结束，最后 8ms 的短帧不再回调
+	ms := time.Millisecond
+	want := []time.Duration{16 * ms, 32 * ms, 48 * ms}
+	if len(frames) != len(want) {
+		t.Fatalf("帧 = %v, 期望 %v", frames, want)
+	}
+	for i, w := range want {
+		if frames[i] != w {
+			t.Errorf("第 %d 帧 elapsed = %v, 期望 %v", i, frames[i], w)
+		}
+	}
+	if d.Clock().Now().Sub(time.Time{}) != 56*ms {
+		t.Errorf("时钟应推进 56ms，实际 %v", d.Clock().Now().Sub(time.Time{}))
+	}
+	if d.Active() != 0 {
+		t.Errorf("动画应已结束，进行中 %d 个", d.Active())
+	}
+}
+
+func TestManualDriverStopAndNested(t *testing.T) {
+	d := NewManualDriver(nil)
+	d.Interval = 10 * time.Millisecond
+
+	calls := 0
+	stop := d.Start(func(time.Duration) bool { calls++; return true })
+	d.Step()
+	stop()
+	stop()
+	d.Step()
+	if calls != 1 || d.Active() != 0 {
+		t.Errorf("stop 后不应再回调: calls=%d active=%d", calls, d.Active())
+	}
+
+	// 回调中开始新的动画，新动画从下一帧开始
+	var nested []time.Duration
+	d.Start(func(time.Duration) bool {
+		d.Start(func(e time.Duration) bool {
+			nested = append(nested, e)
+			return false
+		})
+		return false
+	})
+	d.Step()
+	d.Step()
+	if len(nested) != 1 || nested[0] != 10*time.Millisecond {
+		t.Errorf("嵌套动画帧 = %v", nested)
+	}
+}
+
+func TestManualDriverSettle(t *testing.T) {
+	d := NewManualDriver(nil)
+	s := NewSpring(SpringDefault, 0)
+	s.SetTarget(1)
+	last := d.Clock().Now()
+	d.Start(func(time.Duration) bool {
+		now := d.Clock().Now()
+		s.Step(now.Sub(last))
+		last = now
+		return !s.AtRest()
+	})
+	if !d.Settle(5 * time.Second) {
+		t.Fatal("弹簧应在 5 秒内静止")
+	}
+	if s.Value() != 1 {
+		t.Errorf("静止值 = %v", s.Value())
+	}
+
+	d.Start(func(time.Duration) bool { return true })
+	if d.Settle(100 * time.Millisecond) {
+		t.Error("永不结束的动画应返回 false")
+	}
+}
+
+func TestFyneDriver(t *testing.T) {
+	// 测试 App 的驱动在开始时只回调一帧
+	clock := NewManualClock(time.Time{})
+	var frames []time.Duration
+	stop := FyneDriver{Clock: clock}.Start(func(elapsed time.Duration) bool {
+		frames = append(frames, elapsed)
+		return false
+	})
+	stop()
+	if len(frames) != 1 || frames[0] != 0 {
+		t.Errorf("帧 = %v", frames)
+	}
+}
+
+// toggleFrame 按滑块进度画出简化的开关外观
+func toggleFrame(progress float64) *vector.Drawing {
+	track := color.NRGBA{R: 189, G: 189, B: 189, A: 255}
+	on := color.NRGBA{R: 52, G: 152, B: 219, A: 255}
+	mix := func(a, b uint8) uint8 { return uint8(Lerp(float64(a), float64(b), progress) + 0.5) }
+	x := float32(Lerp(15, 45, progress))
+	return vector.NewDrawing(fyne.NewSize(60, 30)).Add(
+		&vector.RoundRect{Size: fyne.NewSize(60, 30), Radius: 15, Paint: vector.Paint{
+			Fill: color.NRGBA{mix(track.R, on.R), mix(track.G, on.G), mix(track.B, on.B), 255},
+		}},
+		&vector.Circle{Center: fyne.NewPos(x, 15), Radius: 12, Paint: vector.Paint{Fill: color.White}},
+	)
+}
+
+// 用 ManualDriver 把动画推进到关键帧并与金标准截图比较，
+// 这是控件金标准测试的写法：控件的 Driver 和 Timeline 共用 ManualDriver 的时钟
+// 设置 GOLDEN_UPDATE=1 重新生成 testdata/golden 下的图片
+func TestGoldenKeyFrames(t *testing.T) {
+	for _, checked := range []bool{true, false} {
+		d := NewManualDriver(nil)
+		from, to := 0.0, 1.0
+		name := "on"
+		if !checked {
+			from, to, name = 1, 0, "off"
+		}
+		tl := NewTimeline(d.Clock()).Add("knob", Tween{From: from, To: to, Timing: Timing{
+			Duration: 192 * time.Millisecond, Easing: OutCubic,
+		}})
+		tl.Start()
+		d.Start(func(time.Duration) bool { return !tl.Done() })
+
+		var r vector.Rasterizer
+		for _, kf := range []struct {
+			frame string
+			at    time.Duration
+		}{
+			{"start", 0},
+			{"mid", 96 * time.Millisecond},
+			{"end", 192 * time.Millisecond},
+		} {
+			d.Advance(kf.at - tl.Elapsed())
+			img := r.Rasterize(toggleFrame(tl.Value("knob")), 2)
+			golden.Assert(t, "toggle_"+name+"_"+kf.frame, img, golden.Options{})
+		}
+		if d.Active() != 0 {
+			t.Errorf("%s: 动画结束后驱动中不应还有动画", name)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// easing.go
+package anim
+
+import "math"
+
+// Easing 缓动函数，输入 0~1 的时间进度，输出动画进度
+// 所有缓动都满足 f(0)=0、f(1)=1，中间值可以超出 0~1（回弹、弹性）
+type Easing func(t float64) float64
+
+const (
+	backC1    = 1.70158
+	backC2    = backC1 * 1.525
+	backC3    = backC1 + 1
+	elasticC4 = 2 * math.Pi / 3
+	elasticC5 = 2 * math.Pi / 4.5
+)
+
+// Linear 线性
+func Linear(t float64) float64 { return t }
+
+// InCubic 三次方加速
+func InCubic(t float64) float64 { return t * t * t }
+
+// OutCubic 三次方减速
+func OutCubic(t float64) float64 { return 1 - math.Pow(1-t, 3) }
+
+// InOutCubic 三次方先加速后减速
+func InOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 3)/2
+}
+
+// InBack 开始时先向后回拉
+func InBack(t float64) float64 { return backC3*t*t*t - backC1*t*t }
+
+// OutBack 结束时冲过终点再回弹
+func OutBack(t float64) float64 {
+	return 1 + backC3*math.Pow(t-1, 3) + backC1*math.Pow(t-1, 2)
+}
+
+// InOutBack 两端都有回弹
+func InOutBack(t float64) float64 {
+	if t < 0.5 {
+		return math.Pow(2*t, 2) * ((backC2+1)*2*t - backC2) / 2
+	}
+	return (math.Pow(2*t-2, 2)*((backC2+1)*(t*2-2)+backC2) + 2) / 2
+}
+
+// InElastic 开始时弹性振荡
+func InElastic(t float64) float64 {
+	if t <= 0 || t >= 1 {
+		return clampEnd(t)
+	}
+	return -math.Pow(2, 10*t-10) * math.Sin((t*10-10.75)*elasticC4)
+}
+
+// OutElastic 结束时弹性振荡
+func OutElastic(t float64) float64 {
+	if t <= 0 || t >= 1 {
+		return clampEnd(t)
+	}
+	return math.Pow(2, -10*t)*math.Sin((t*10-0.75)*elasticC4) + 1
+}
+
+// InOutElastic 两端弹性振荡
+func InOutElastic(t float64) float64 {
+	if t <= 0 || t >= 1 {
+		return clampEnd(t)
+	}
+	if t < 0.5 {
+		return -(math.Pow(2, 20*t-10) * math.Sin((20*t-11.125)*elasticC5)) / 2
+	}
+	return math.Pow(2, -20*t+10)*math.Sin((20*t-11.125)*elasticC5)/2 + 1
+}
+
+// SpringEasing 欠阻尼弹簧曲线，适合固定时长的弹跳效果
+func SpringEasing(t float64) float64 {
+	if t <= 0 || t >= 1 {
+		return clampEnd(t)
+	}
+	// 振幅在 t=1 时衰减到 e^-6（约 0.25%），再乘以 (1-t) 保证终点精确为 1
+	return 1 - math.Exp(-6*t)*math.Cos(3*math.Pi*t)*(1-t)
+}
+
+func clampEnd(t float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	return 1
+}
+
+// EasingByName 按名称查找缓动函数，便于在样式配置中用字符串指定
+// 未知名称返回 nil
+func EasingByName(name string) Easing {
+	return easings[name]
+}
+
+var easings = map[string]Easing{
+	"linear":       Linear,
+	"inCubic":      InCubic,
+	"outCubic":     OutCubic,
+	"inOutCubic":   InOutCubic,
+	"inBack":       InBack,
+	"outBack":      OutBack,
+	"inOutBack":    InOutBack,
+	"inElastic":    InElastic,
+	"outElastic":   OutElastic,
+	"inOutElastic": InOutElastic,
+	"spring":       SpringEasing,
+}
@@ -0,0 +1,67 @@
+package anim
+
+import (
+	"math"
+	"testing"
+)
+
+const eps = 1e-9
+
+var allEasings = []struct {
+	name string
+	fn   Easing
+}{
+	{"linear", Linear},
+	{"inCubic", InCubic},
+	{"outCubic", OutCubic},
+	{"inOutCubic", InOutCubic},
+	{"inBack", InBack},
+	{"outBack", OutBack},
+	{"inOutBack", InOutBack},
+	{"inElastic", InElastic},
+	{"outElastic", OutElastic},
+	{"inOutElastic", InOutElastic},
+	{"spring", SpringEasing},
+}
+
+func TestEasingEndpoints(t *testing.T) {
+	for _, e := range allEasings {
+		t.Run(e.name, func(t *testing.T) {
+			if got := e.fn(0); math.Abs(got) > eps {
+				t.Errorf("f(0) = %v, 期望 0", got)
+			}
+			if got := e.fn(1); math.Abs(got-1) > eps {
+				t.Errorf("f(1) = %v, 期望 1", got)
+			}
+			if EasingByName(e.name) == nil {
+				t.Errorf("EasingByName(%q) 返回 nil", e.name)
+			}
+		})
+	}
+	if EasingByName("unknown") != nil {
+		t.Error("未知名称应返回 nil")
+	}
+}
+
+func TestEasingShapes(t *testing.T) {
+	tests := []struct {
+		name  string
+		fn    Easing
+		t     float64
+		check func(v float64) bool
+		desc  string
+	}{
+		{"inCubic", InCubic, 0.5, func(v float64) bool { return math.Abs(v-0.125) < eps }, "= 0.125"},
+		{"outCubic", OutCubic, 0.5, func(v float64) bool { return math.Abs(v-0.875) < eps }, "= 0.875"},
+		{"inOutCubic", InOutCubic, 0.5, func(v float64) bool { return math.Abs(v-0.5) < eps }, "= 0.5"},
+		{"inBack", InBack, 0.2, func(v float64) bool { return v < 0 }, "< 0（向后回拉）"},
+		{"outBack", OutBack, 0.8, func(v float64) bool { return v > 1 }, "> 1（冲过终点）"},
+		{"outElastic", OutElastic, 0.1, func(v float64) bool { return v > 1 }, "> 1（振荡）"},
+		{"spring", SpringEasing, 0.4, func(v float64) bool { return v > 1 }, "> 1（回弹）"},
+	}
+	for _, tt := range tests {
+		if v := tt.fn(tt.t); !tt.check(v) {
+			t.Errorf("%s(%v) = %v, 期望 %s", tt.name, tt.t, v, tt.desc)
+		}
+	}
+}
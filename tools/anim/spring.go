@@ -0,0 +1,130 @@
+// spring.go
+package anim
+
+import (
+	"math"
+	"time"
+)
+
+// SpringConfig 弹簧参数
+type SpringConfig struct {
+	Stiffness float64 // 刚度，越大越快
+	Damping   float64 // 阻尼，越大振荡越少，0 表示使用 SpringDefault 的阻尼
+	Mass      float64 // 质量，越大越迟缓
+	// RestDelta / RestSpeed 距离目标和速度都小于该值时视为静止，0 表示使用默认值 0.001
+	RestDelta float64
+	RestSpeed float64
+}
+
+// 常用弹簧预设
+var (
+	SpringDefault = SpringConfig{Stiffness: 170, Damping: 26, Mass: 1}
+	SpringGentle  = SpringConfig{Stiffness: 120, Damping: 14, Mass: 1}
+	SpringWobbly  = SpringConfig{Stiffness: 180, Damping: 12, Mass: 1}
+	SpringStiff   = SpringConfig{Stiffness: 210, Damping: 20, Mass: 1}
+)
+
+// DampingRatio 阻尼比，小于 1 时会越过目标再回弹，等于 1 为临界阻尼
+func (c SpringConfig) DampingRatio() float64 {
+	return c.Damping / (2 * math.Sqrt(c.Stiffness*c.Mass))
+}
+
+func (c SpringConfig) withDefaults() SpringConfig {
+	if c.Stiffness <= 0 {
+		c.Stiffness = SpringDefault.Stiffness
+	}
+	// 没有阻尼的弹簧永远不会静止
+	if c.Damping <= 0 {
+		c.Damping = SpringDefault.Damping
+	}
+	if c.Mass <= 0 {
+		c.Mass = 1
+	}
+	if c.RestDelta <= 0 {
+		c.RestDelta = 0.001
+	}
+	if c.RestSpeed <= 0 {
+		c.RestSpeed = 0.001
+	}
+	return c
+}
+
+const (
+	// springSubstep 积分子步长，保证常用刚度下数值稳定
+	springSubstep = time.Millisecond
+	// springMaxStep 单次 Step 最多推进的时长
+	// 窗口被挂起后恢复时 dt 可能很大，限制后最多积分 250 个子步
+	springMaxStep = 250 * time.Millisecond
+)
+
+// Spring 基于物理的弹簧动画
+// 与固定时长的 Tween 不同，动画途中调用 SetTarget 时会保留当前位置和速度，
+// 所以快速连续切换开关或点击多个步骤时运动是连续的
+type Spring struct {
+	cfg      SpringConfig
+	value    float64
+	velocity float64
+	target   float64
+}
+
+// NewSpring 创建静止在 value 处的弹簧
+func NewSpring(cfg SpringConfig, value float64) *Spring {
+	return &Spring{cfg: cfg.withDefaults(), value: value, target: value}
+}
+
+// SetConfig 修改弹簧参数，当前位置和速度保持不变
+func (s *Spring) SetConfig(cfg SpringConfig) {
+	s.cfg = cfg.withDefaults()
+}
+
+// Config 返回当前参数
+func (s *Spring) Config() SpringConfig { return s.cfg }
+
+// SetTarget 修改目标值，从当前位置和速度继续运动
+func (s *Spring) SetTarget(target float64) {
+	s.target = target
+}
+
+// Snap 直接跳到 value 并停止
+func (s *Spring) Snap(value float64) {
+	s.value, s.target, s.velocity = value, value, 0
+}
+
+// Value 当前值
+func (s *Spring) Value() float64 { return s.value }
+
+// Velocity 当前速度（每秒）
+func (s *Spring) Velocity() float64 { return s.velocity }
+
+// Target 目标值
+func (s *Spring) Target() float64 { return s.target }
+
+// AtRest 是否已静止在目标处
+func (s *Spring) AtRest() bool {
+	return math.Abs(s.target-s.value) < s.cfg.RestDelta && math.Abs(s.velocity) < s.cfg.RestSpeed
+}
+
+// Step 推进 dt 并返回新的值；减少动画模式下直接到达目标
+// dt 超过 springMaxStep 时按 springMaxStep 计算
+func (s *Spring) Step(dt time.Duration) float64 {
+	if ReducedMotion() {
+		s.Snap(s.target)
+		return s.value
+	}
+	dt = min(dt, springMaxStep)
+
+	for dt > 0 && !s.AtRest() {
+		h := min(dt, springSubstep)
+		dt -= h
+		sec := h.Seconds()
+
+		// 半隐式欧拉：先更新速度，再用新速度更新位置
+		force := -s.cfg.Stiffness*(s.value-s.target) - s.cfg.Damping*s.velocity
+		s.velocity += force / s.cfg.Mass * sec
+		s.value += s.velocity * sec
+	}
+	if s.AtRest() {
+		s.value, s.velocity = s.target, 0
+	}
+	return s.value
+}
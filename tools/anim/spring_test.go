@@ -0,0 +1,158 @@
+package anim
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+const frame = 16 * time.Millisecond
+
+// settle 逐帧推进直到静止，返回耗时和过程中的最大值
+func settle(s *Spring, limit time.Duration) (time.Duration, float64) {
+	var elapsed time.Duration
+	peak := s.Value()
+	for !s.AtRest() && elapsed < limit {
+		peak = math.Max(peak, s.Step(frame))
+		elapsed += frame
+	}
+	return elapsed, peak
+}
+
+func TestSpringSettles(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       SpringConfig
+		overshoot bool
+	}{
+		{"默认", SpringDefault, false},
+		{"柔和", SpringGentle, true},
+		{"弹跳", SpringWobbly, true},
+		{"临界阻尼", SpringConfig{Stiffness: 100, Damping: 20, Mass: 1}, false},
+		{"质量更大", SpringConfig{Stiffness: 170, Damping: 26, Mass: 3}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSpring(tt.cfg, 0)
+			s.SetTarget(1)
+			elapsed, peak := settle(s, 10*time.Second)
+
+			if !s.AtRest() {
+				t.Fatalf("10 秒内未静止，当前值 %v 速度 %v", s.Value(), s.Velocity())
+			}
+			if s.Value() != 1 || s.Velocity() != 0 {
+				t.Errorf("静止后应精确停在目标处: value=%v velocity=%v", s.Value(), s.Velocity())
+			}
+			if got := peak > 1+1e-6; got != tt.overshoot {
+				t.Errorf("越过目标 = %v（峰值 %v，阻尼比 %.2f），期望 %v",
+					got, peak, tt.cfg.DampingRatio(), tt.overshoot)
+			}
+			t.Logf("静止耗时 %v，峰值 %.4f", elapsed, peak)
+		})
+	}
+}
+
+func TestSpringRetargetIsContinuous(t *testing.T) {
+	s := NewSpring(SpringDefault, 0)
+	s.SetTarget(1)
+	for i := 0; i < 6; i++ {
+		s.Step(frame)
+	}
+	value, velocity := s.Value(), s.Velocity()
+	if velocity <= 0 {
+		t.Fatalf("中途速度应为正，实际 %v", velocity)
+	}
+
+	// 中途反向：位置和速度不能跳变
+	s.SetTarget(0)
+	if s.Value() != value || s.Velocity() != velocity {
+		t.Fatal("SetTarget 不应改变当前位置和速度")
+	}
+
+	prev := s.Value()
+	next := s.Step(time.Millisecond)
+	if step := next - prev; step <= 0 || step > velocity*0.002 {
+		t.Errorf("反向后的第一毫秒应沿原方向继续小幅移动，实际变化 %v（速度 %v）", step, velocity)
+	}
+
+	settle(s, 10*time.Second)
+	if !s.AtRest() || s.Value() != 0 {
+		t.Errorf("反向后应静止在新目标 0，实际 %v", s.Value())
+	}
+}
+
+func TestSpringFrameRateIndependent(t *testing.T) {
+	a := NewSpring(SpringWobbly, 0)
+	b := NewSpring(SpringWobbly, 0)
+	a.SetTarget(100)
+	b.SetTarget(100)
+
+	// 相同总时长，一个用 60fps，一个用 30fps
+	for i := 0; i < 30; i++ {
+		a.Step(frame)
+	}
+	for i := 0; i < 15; i++ {
+		b.Step(2 * frame)
+	}
+	if math.Abs(a.Value()-b.Value()) > 1e-6 {
+		t.Errorf("不同帧率结果不一致: %v vs %v", a.Value(), b.Value())
+	}
+}
+
+func TestSpringLargeStepIsBounded(t *testing.T) {
+	a := NewSpring(SpringWobbly, 0)
+	b := NewSpring(SpringWobbly, 0)
+	a.SetTarget(1)
+	b.SetTarget(1)
+
+	// 窗口挂起一小时后恢复，只按 springMaxStep 推进
+	a.Step(time.Hour)
+	b.Step(springMaxStep)
+	if a.Value() != b.Value() || a.Velocity() != b.Velocity() {
+		t.Errorf("超大 dt 应按 %v 计算: %v vs %v", springMaxStep, a.Value(), b.Value())
+	}
+}
+
+func TestSpringSnapAndReducedMotion(t *testing.T) {
+	s := NewSpring(SpringDefault, 0)
+	s.SetTarget(50)
+	s.Step(frame)
+	s.Snap(10)
+	if s.Value() != 10 || s.Target() != 10 || !s.AtRest() {
+		t.Error("Snap 后应静止在指定值")
+	}
+
+	SetReducedMotion(true)
+	defer SetReducedMotion(false)
+	s.SetTarget(80)
+	if got := s.Step(frame); got != 80 || !s.AtRest() {
+		t.Errorf("减少动画模式下应直接到达目标，实际 %v", got)
+	}
+}
+
+func TestSpringConfigDefaults(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  SpringConfig
+	}{
+		{"零值", SpringConfig{}},
+		{"只设置刚度", SpringConfig{Stiffness: 300}},
+		{"负阻尼", SpringConfig{Stiffness: 170, Damping: -5, Mass: 1}},
+	}
+	for _, tt := range tests {
+		s := NewSpring(tt.cfg, 0)
+		cfg := s.Config()
+		if cfg.Stiffness <= 0 || cfg.Damping <= 0 || cfg.Mass <= 0 || cfg.RestDelta <= 0 || cfg.RestSpeed <= 0 {
+			t.Errorf("%s: 应补全默认值: %+v", tt.name, cfg)
+		}
+		// 补全后的弹簧必须能静止
+		s.SetTarget(1)
+		if settle(s, 10*time.Second); !s.AtRest() || s.Value() != 1 {
+			t.Errorf("%s: 10 秒内未静止，当前值 %v", tt.name, s.Value())
+		}
+	}
+
+	if r := (SpringConfig{Stiffness: 100, Damping: 20, Mass: 1}).DampingRatio(); math.Abs(r-1) > 1e-9 {
+		t.Errorf("DampingRatio = %v, 期望 1", r)
+	}
+}
@@ -0,0 +1,187 @@
+// tween.go
+package anim
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Clock 时间来源，测试中可以替换为 ManualClock 逐帧推进
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// SystemClock 使用系统时间的时钟
+var SystemClock Clock = systemClock{}
+
+// ManualClock 手动推进的时钟，用于确定性的逐帧测试
+type ManualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewManualClock 创建从 start 开始的手动时钟
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+// Now 返回当前时间
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance 向前推进 d
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+var reducedMotion atomic.Bool
+
+// SetReducedMotion 设置全局减少动画模式，开启后所有时间轴直接跳到结束状态
+func SetReducedMotion(on bool) {
+	reducedMotion.Store(on)
+}
+
+// ReducedMotion 是否处于减少动画模式
+func ReducedMotion() bool {
+	return reducedMotion.Load()
+}
+
+// Timing 动画时间参数，供各控件样式配置使用
+type Timing struct {
+	Duration time.Duration
+	Delay    time.Duration
+	Easing   Easing // 为 nil 时使用 Linear
+}
+
+// Total 延迟加时长
+func (tm Timing) Total() time.Duration {
+	return tm.Delay + tm.Duration
+}
+
+// Progress 返回经过 elapsed 后的缓动进度
+// 延迟期间为 0，结束后为 1；Duration 为 0 时延迟结束立即为 1
+func (tm Timing) Progress(elapsed time.Duration) float64 {
+	t := elapsed - tm.Delay
+	switch {
+	case t < 0:
+		return 0
+	case t >= tm.Duration:
+		return 1
+	}
+	ease := tm.Easing
+	if ease == nil {
+		ease = Linear
+	}
+	return ease(float64(t) / float64(tm.Duration))
+}
+
+// Tween 从 From 到 To 的补间
+type Tween struct {
+	From, To float64
+	Timing
+}
+
+// At 返回经过 elapsed 后的值
+func (tw Tween) At(elapsed time.Duration) float64 {
+	return Lerp(tw.From, tw.To, tw.Progress(elapsed))
+}
+
+// Done 经过 elapsed 后补间是否已结束
+func (tw Tween) Done(elapsed time.Duration) bool {
+	return elapsed >= tw.Total()
+}
+
+// Lerp 线性插值
+func Lerp(from, to, p float64) float64 {
+	return from + (to-from)*p
+}
+
+// Timeline 一组同时开始的具名补间，每个补间可以有自己的延迟，
+// 组合起来实现先后衔接的动画（例如复选框先画圆再画对勾）
+type Timeline struct {
+	clock   Clock
+	tweens  map[string]Tween
+	order   []string
+	start   time.Time
+	running bool
+	reduced bool
+}
+
+// NewTimeline 创建时间轴，clock 为 nil 时使用 SystemClock
+func NewTimeline(clock Clock) *Timeline {
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &Timeline{clock: clock, tweens: make(map[string]Tween)}
+}
+
+// Add 添加或替换一个补间，支持链式调用
+func (tl *Timeline) Add(name string, tw Tween) *Timeline {
+	if _, ok := tl.tweens[name]; !ok {
+		tl.order = append(tl.order, name)
+	}
+	tl.tweens[name] = tw
+	return tl
+}
+
+// Start 从当前时间开始播放；减少动画模式下直接进入结束状态
+func (tl *Timeline) Start() {
+	tl.start = tl.clock.Now()
+	tl.running = true
+	tl.reduced = ReducedMotion()
+}
+
+// Elapsed 已播放的时间，未开始时为 0
+func (tl *Timeline) Elapsed() time.Duration {
+	if !tl.running {
+		return 0
+	}
+	return tl.clock.Now().Sub(tl.start)
+}
+
+// Duration 整条时间轴的时长（最晚结束的补间）
+func (tl *Timeline) Duration() time.Duration {
+	var d time.Duration
+	for _, tw := range tl.tweens {
+		d = max(d, tw.Total())
+	}
+	return d
+}
+
+// Value 返回补间 name 的当前值，未知名称返回 0
+// 未开始时返回起始值
+func (tl *Timeline) Value(name string) float64 {
+	tw, ok := tl.tweens[name]
+	switch {
+	case !ok:
+		return 0
+	case tl.reduced:
+		return tw.To
+	case !tl.running:
+		return tw.From
+	}
+	return tw.At(tl.Elapsed())
+}
+
+// Done 时间轴是否已播放完毕
+func (tl *Timeline) Done() bool {
+	if !tl.running {
+		return false
+	}
+	return tl.reduced || tl.Elapsed() >= tl.Duration()
+}
+
+// Names 按添加顺序返回补间名称
+func (tl *Timeline) Names() []string {
+	return append([]string(nil), tl.order...)
+}
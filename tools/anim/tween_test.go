@@ -0,0 +1,95 @@
+package anim
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTimingDelayAndDuration(t *testing.T) {
+	tw := Tween{From: 10, To: 20, Timing: Timing{Duration: 100 * time.Millisecond, Delay: 50 * time.Millisecond}}
+
+	tests := []struct {
+		elapsed time.Duration
+		want    float64
+		done    bool
+	}{
+		{0, 10, false},
+		{50 * time.Millisecond, 10, false},
+		{100 * time.Millisecond, 15, false},
+		{150 * time.Millisecond, 20, true},
+		{time.Second, 20, true},
+	}
+	for _, tt := range tests {
+		if got := tw.At(tt.elapsed); math.Abs(got-tt.want) > eps {
+			t.Errorf("At(%v) = %v, 期望 %v", tt.elapsed, got, tt.want)
+		}
+		if got := tw.Done(tt.elapsed); got != tt.done {
+			t.Errorf("Done(%v) = %v, 期望 %v", tt.elapsed, got, tt.done)
+		}
+	}
+
+	// 零时长在延迟结束后直接到终点
+	zero := Timing{Delay: 10 * time.Millisecond}
+	if zero.Progress(5*time.Millisecond) != 0 || zero.Progress(10*time.Millisecond) != 1 {
+		t.Error("零时长补间应在延迟结束后立即完成")
+	}
+}
+
+func TestTimelineSequence(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	// 复选框：圆先放大，对勾在 100ms 后开始绘制
+	tl := NewTimeline(clock).
+		Add("circle", Tween{From: 0, To: 1, Timing: Timing{Duration: 100 * time.Millisecond}}).
+		Add("check", Tween{From: 0, To: 1, Timing: Timing{Duration: 100 * time.Millisecond, Delay: 100 * time.Millisecond}})
+
+	if tl.Value("circle") != 0 || tl.Done() {
+		t.Fatal("未开始时应为起始值")
+	}
+	if tl.Duration() != 200*time.Millisecond {
+		t.Errorf("Duration = %v, 期望 200ms", tl.Duration())
+	}
+
+	tl.Start()
+	clock.Advance(50 * time.Millisecond)
+	if got := tl.Value("circle"); math.Abs(got-0.5) > eps {
+		t.Errorf("50ms 时 circle = %v, 期望 0.5", got)
+	}
+	if got := tl.Value("check"); got != 0 {
+		t.Errorf("50ms 时 check = %v, 期望 0", got)
+	}
+
+	clock.Advance(100 * time.Millisecond)
+	if tl.Value("circle") != 1 || math.Abs(tl.Value("check")-0.5) > eps {
+		t.Errorf("150ms 时 circle=%v check=%v", tl.Value("circle"), tl.Value("check"))
+	}
+	if tl.Done() {
+		t.Error("150ms 时不应结束")
+	}
+
+	clock.Advance(50 * time.Millisecond)
+	if !tl.Done() || tl.Value("check") != 1 {
+		t.Error("200ms 时应结束")
+	}
+	if names := tl.Names(); len(names) != 2 || names[0] != "circle" {
+		t.Errorf("Names = %v", names)
+	}
+}
+
+func TestTimelineReducedMotion(t *testing.T) {
+	SetReducedMotion(true)
+	defer SetReducedMotion(false)
+
+	clock := NewManualClock(time.Unix(0, 0))
+	tl := NewTimeline(clock).
+		Add("knob", Tween{From: 0, To: 80, Timing: Timing{Duration: time.Second, Delay: time.Second, Easing: OutBack}})
+	tl.Start()
+
+	// 不推进时钟，也应直接处于结束状态
+	if got := tl.Value("knob"); got != 80 {
+		t.Errorf("减少动画模式下应直接为终值，实际 %v", got)
+	}
+	if !tl.Done() {
+		t.Error("减少动画模式下应立即结束")
+	}
+}
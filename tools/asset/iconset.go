@@ -0,0 +1,104 @@
+// iconset.go
+package asset
+
+import (
+	"fmt"
+	"path"
+
+	"fyne.io/fyne/v2"
+)
+
+// IconState 图标所处的交互状态
+type IconState int
+
+const (
+	IconNormal IconState = iota
+	IconHover
+	IconSelected
+	IconDisabled
+)
+
+// String 返回状态名称，同时用于生成着色后资源的名称
+func (s IconState) String() string {
+	switch s {
+	case IconNormal:
+		return "normal"
+	case IconHover:
+		return "hover"
+	case IconSelected:
+		return "selected"
+	case IconDisabled:
+		return "disabled"
+	default:
+		return fmt.Sprintf("state%d", int(s))
+	}
+}
+
+// IconVariant 某个状态下的图标：来源加上着色规则
+// Source 为空时沿用 Normal 状态的来源，只换颜色；Rules 为空时保留原始多色图稿
+type IconVariant struct {
+	Source Source
+	Rules  []RecolorRule
+}
+
+// IconSet 一个图标在各交互状态下的变体，没有单独设置的状态使用 Normal
+type IconSet struct {
+	variants map[IconState]IconVariant
+}
+
+// NewIconSet 创建图标集，normal 为默认状态的变体
+func NewIconSet(normal IconVariant) *IconSet {
+	return &IconSet{variants: map[IconState]IconVariant{IconNormal: normal}}
+}
+
+// Set 设置某个状态的变体，返回自身以便链式调用
+func (s *IconSet) Set(state IconState, v IconVariant) *IconSet {
+	s.variants[state] = v
+	return s
+}
+
+// Variant 返回状态实际使用的变体，已经补全了沿用的来源
+func (s *IconSet) Variant(state IconState) IconVariant {
+	normal := s.variants[IconNormal]
+	v, ok := s.variants[state]
+	if !ok {
+		return normal
+	}
+	if v.Source.IsZero() {
+		v.Source = normal.Source
+	}
+	return v
+}
+
+// Key 返回状态对应图标的缓存键，来源或着色规则不同的状态得到不同的键
+func (s *IconSet) Key(state IconState) string {
+	v := s.Variant(state)
+	if len(v.Rules) == 0 {
+		return v.Source.Key()
+	}
+	return v.Source.Key() + "#" + fmt.Sprint(v.Rules)
+}
+
+// Load 用加载器 l（nil 时使用 Default）读取并着色状态对应的图标
+// 着色后的资源名称带上状态，例如 "check.hover.svg"，避免与原图共用 Fyne 的 SVG 缓存
+func (s *IconSet) Load(l *Loader, state IconState) (fyne.Resource, error) {
+	if l == nil {
+		l = Default
+	}
+	v := s.Variant(state)
+	res, err := l.Load(v.Source)
+	if err != nil {
+		return nil, err
+	}
+	if len(v.Rules) == 0 {
+		return res, nil
+	}
+
+	data, err := Recolor(res.Content(), v.Rules...)
+	if err != nil {
+		return nil, fmt.Errorf("图标着色失败 %s: %w", res.Name(), err)
+	}
+	ext := path.Ext(res.Name())
+	name := res.Name()[:len(res.Name())-len(ext)] + "." + state.String() + ext
+	return fyne.NewStaticResource(name, data), nil
+}
@@ -0,0 +1,82 @@
+package asset
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const checkSVG = `<svg><path class="mark" d="M0 0" fill="#000000"/></svg>`
+
+func newTestIconSet() *IconSet {
+	return NewIconSet(IconVariant{Source: FromPath("svg/check.svg")}).
+		Set(IconHover, IconVariant{Rules: []RecolorRule{{Selector: ".mark", Fill: color.RGBA{52, 152, 219, 255}}}}).
+		Set(IconDisabled, IconVariant{
+			Source: FromBytes("check-off.svg", []byte(`<svg><path class="mark" d="M1 1"/></svg>`)),
+			Rules:  []RecolorRule{{Selector: "*", Fill: color.Gray{128}}},
+		})
+}
+
+func TestIconSetLoad(t *testing.T) {
+	l := NewLoader(fstest.MapFS{"svg/check.svg": {Data: []byte(checkSVG)}})
+	l.DiskFallback = false
+	set := newTestIconSet()
+
+	tests := []struct {
+		name     string
+		state    IconState
+		wantName string
+		want     string
+	}{
+		{"Normal 保留原始图稿", IconNormal, "check.svg", checkSVG},
+		{"Selected 未设置时回退到 Normal", IconSelected, "check.svg", checkSVG},
+		{"Hover 沿用 Normal 的来源只换颜色", IconHover, "check.hover.svg", `fill="#3498db"`},
+		{"Disabled 使用自己的来源", IconDisabled, "check-off.disabled.svg", `d="M1 1" fill="#808080"`},
+	}
+	for _, tt := range tests {
+		res, err := set.Load(l, tt.state)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if res.Name() != tt.wantName {
+			t.Errorf("%s: 资源名称 = %q, 期望 %q", tt.name, res.Name(), tt.wantName)
+		}
+		if !strings.Contains(string(res.Content()), tt.want) {
+			t.Errorf("%s: 内容中没有 %s\n%s", tt.name, tt.want, res.Content())
+		}
+	}
+}
+
+func TestIconSetKey(t *testing.T) {
+	set := newTestIconSet()
+	keys := map[string]IconState{}
+	for _, st := range []IconState{IconNormal, IconHover, IconDisabled} {
+		k := set.Key(st)
+		if prev, ok := keys[k]; ok {
+			t.Errorf("%v 和 %v 的缓存键相同: %s", prev, st, k)
+		}
+		keys[k] = st
+	}
+	if set.Key(IconSelected) != set.Key(IconNormal) {
+		t.Error("回退到 Normal 的状态应使用相同的缓存键")
+	}
+}
+
+func TestIconStateString(t *testing.T) {
+	tests := []struct {
+		state IconState
+		want  string
+	}{
+		{IconNormal, "normal"},
+		{IconHover, "hover"},
+		{IconSelected, "selected"},
+		{IconDisabled, "disabled"},
+		{IconState(9), "state9"},
+	}
+	for _, tt := range tests {
+		if got := tt.state.String(); got != tt.want {
+			t.Errorf("String() = %q, 期望 %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// loader.go
+package asset
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+
+	"2025-12-18-ggAndPng/tools/svgcache"
+)
+
+// sourceKind 来源类型
+type sourceKind int
+
+const (
+	kindNone sourceKind = iota
+	kindResource
+	kindPath
+	kindBytes
+)
+
+// Source 图标/资源来源，三种形式任选其一：
+// fyne.Resource、路径（先查嵌入文件系统，再查磁盘）或原始 SVG 字节
+type Source struct {
+	kind     sourceKind
+	resource fyne.Resource
+	path     string
+	name     string
+	data     []byte
+}
+
+// FromResource 使用已有的 fyne.Resource，res 为 nil 时返回空来源
+func FromResource(res fyne.Resource) Source {
+	if res == nil {
+		return Source{}
+	}
+	return Source{kind: kindResource, resource: res}
+}
+
+// FromPath 使用路径，例如 "svg/1.svg"，p 为空时返回空来源
+func FromPath(p string) Source {
+	if p == "" {
+		return Source{}
+	}
+	return Source{kind: kindPath, path: p}
+}
+
+// FromBytes 使用原始数据（例如内联的 SVG 文本），name 只用于显示和错误信息
+// 即使 data 为空也是一个字节来源，Load 时会返回错误
+func FromBytes(name string, data []byte) Source {
+	return Source{kind: kindBytes, name: name, data: data}
+}
+
+// IsZero 判断是否未设置任何来源
+func (s Source) IsZero() bool {
+	return s.kind == kindNone
+}
+
+// Name 返回来源的显示名称，不同内容的来源可能同名，不能用作缓存键
+func (s Source) Name() string {
+	switch s.kind {
+	case kindResource:
+		return s.resource.Name()
+	case kindPath:
+		return s.path
+	default:
+		return s.name
+	}
+}
+
+// Key 返回可用作缓存键（svgcache.Key.Source）的标识
+// 路径来源使用规范化后的路径；字节和 fyne.Resource 来源使用内容摘要，
+// 所以同名但内容不同的内联 SVG 不会相互覆盖
+func (s Source) Key() string {
+	switch s.kind {
+	case kindResource:
+		return svgcache.HashSource(s.resource.Content())
+	case kindPath:
+		return "path:" + path.Clean(filepath.ToSlash(s.path))
+	case kindBytes:
+		return svgcache.HashSource(s.data)
+	default:
+		return ""
+	}
+}
+
+// ErrNotFound 嵌入文件系统和磁盘上都找不到资源
+var ErrNotFound = errors.New("资源不存在")
+
+// Loader 资源加载器
+// 路径先在 Embedded 中查找，找不到再从磁盘读取（相对路径基于当前工作目录）
+type Loader struct {
+	Embedded fs.FS
+	// DiskFallback 为 false 时只使用嵌入文件系统，适合单文件发布
+	DiskFallback bool
+}
+
+// NewLoader 创建加载器，embedded 可以为 nil
+func NewLoader(embedded fs.FS) *Loader {
+	return &Loader{Embedded: embedded, DiskFallback: true}
+}
+
+// Default 默认加载器，程序启动时可以设置 Default.Embedded 指向 go:embed 的文件系统
+var Default = NewLoader(nil)
+
+// Load 按来源读取资源
+func (l *Loader) Load(src Source) (fyne.Resource, error) {
+	switch src.kind {
+	case kindResource:
+		return src.resource, nil
+	case kindPath:
+		data, err := l.ReadFile(src.path)
+		if err != nil {
+			return nil, err
+		}
+		return fyne.NewStaticResource(path.Base(filepath.ToSlash(src.path)), data), nil
+	case kindBytes:
+		if len(src.data) == 0 {
+			return nil, fmt.Errorf("资源内容为空: %s", src.name)
+		}
+		return fyne.NewStaticResource(src.name, src.data), nil
+	default:
+		return nil, errors.New("未设置资源来源")
+	}
+}
+
+// ReadFile 按路径读取文件内容
+func (l *Loader) ReadFile(p string) ([]byte, error) {
+	if l.Embedded != nil {
+		if name, ok := embeddedName(p); ok {
+			data, err := fs.ReadFile(l.Embedded, name)
+			if err == nil {
+				return data, nil
+			}
+			if !errors.Is(err, fs.ErrNotExist) {
+				return nil, fmt.Errorf("读取嵌入资源失败 %s: %w", p, err)
+			}
+		}
+	}
+
+	if !l.DiskFallback {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, p)
+	}
+	data, err := os.ReadFile(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, p)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取资源失败 %s: %w", p, err)
+	}
+	return data, nil
+}
+
+// Exists 判断路径对应的资源是否存在
+func (l *Loader) Exists(p string) bool {
+	if l.Embedded != nil {
+		if name, ok := embeddedName(p); ok {
+			if _, err := fs.Stat(l.Embedded, name); err == nil {
+				return true
+			}
+		}
+	}
+	if !l.DiskFallback {
+		return false
+	}
+	_, err := os.Stat(p)
+	return err == nil
+}
+
+// embeddedName 把路径转换为 fs.FS 可用的名称（斜杠分隔、无 "./" 前缀）
+func embeddedName(p string) (string, bool) {
+	name := path.Clean(filepath.ToSlash(p))
+	return name, fs.ValidPath(name)
+}
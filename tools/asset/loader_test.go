@@ -0,0 +1,182 @@
+package asset
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"fyne.io/fyne/v2"
+)
+
+const testSVG = `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 1 1"/>`
+
+// chdir 切换到临时目录，测试结束后恢复
+func chdir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Chdir(dir)
+	return dir
+}
+
+func TestLoaderPrefersEmbedded(t *testing.T) {
+	dir := chdir(t)
+	if err := os.MkdirAll(filepath.Join(dir, "svg"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "svg", "1.svg"), []byte("disk"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	l := NewLoader(fstest.MapFS{
+		"svg/1.svg": {Data: []byte("embedded")},
+	})
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"svg/1.svg", "embedded"},
+		{"./svg/1.svg", "embedded"},
+	}
+	for _, tt := range tests {
+		res, err := l.Load(FromPath(tt.path))
+		if err != nil {
+			t.Fatalf("Load(%q) 失败: %v", tt.path, err)
+		}
+		if got := string(res.Content()); got != tt.want {
+			t.Errorf("Load(%q) = %q, 期望 %q", tt.path, got, tt.want)
+		}
+		if res.Name() != "1.svg" {
+			t.Errorf("资源名称 = %q, 期望 1.svg", res.Name())
+		}
+	}
+}
+
+func TestLoaderFallsBackToDisk(t *testing.T) {
+	dir := chdir(t)
+	if err := os.WriteFile(filepath.Join(dir, "2.svg"), []byte("disk"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	l := NewLoader(fstest.MapFS{})
+	res, err := l.Load(FromPath("2.svg"))
+	if err != nil {
+		t.Fatalf("Load 失败: %v", err)
+	}
+	if string(res.Content()) != "disk" {
+		t.Errorf("应从磁盘读取，实际 %q", res.Content())
+	}
+	if !l.Exists("2.svg") {
+		t.Error("Exists 应返回 true")
+	}
+
+	// 关闭磁盘回退后只查嵌入文件系统
+	l.DiskFallback = false
+	if _, err := l.Load(FromPath("2.svg")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("关闭磁盘回退后应返回 ErrNotFound，实际 %v", err)
+	}
+	if l.Exists("2.svg") {
+		t.Error("关闭磁盘回退后 Exists 应返回 false")
+	}
+}
+
+func TestLoaderNotFound(t *testing.T) {
+	chdir(t)
+	l := NewLoader(nil)
+	if _, err := l.Load(FromPath("svg/missing.svg")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("期望 ErrNotFound，实际 %v", err)
+	}
+	if l.Exists("svg/missing.svg") {
+		t.Error("Exists 应返回 false")
+	}
+}
+
+func TestLoaderResourceAndBytes(t *testing.T) {
+	l := NewLoader(nil)
+
+	static := fyne.NewStaticResource("icon.svg", []byte(testSVG))
+	res, err := l.Load(FromResource(static))
+	if err != nil || res != static {
+		t.Errorf("fyne.Resource 应原样返回，err=%v", err)
+	}
+
+	res, err = l.Load(FromBytes("inline.svg", []byte(testSVG)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Name() != "inline.svg" || string(res.Content()) != testSVG {
+		t.Errorf("原始字节加载结果错误: %q", res.Name())
+	}
+
+	if _, err := l.Load(Source{}); err == nil {
+		t.Error("空来源应返回错误")
+	}
+}
+
+func TestSourceName(t *testing.T) {
+	tests := []struct {
+		src  Source
+		want string
+	}{
+		{FromPath("svg/1.svg"), "svg/1.svg"},
+		{FromBytes("inline", nil), "inline"},
+		{FromResource(fyne.NewStaticResource("res.svg", nil)), "res.svg"},
+	}
+	for _, tt := range tests {
+		if got := tt.src.Name(); got != tt.want {
+			t.Errorf("Name() = %q, 期望 %q", got, tt.want)
+		}
+	}
+}
+
+func TestSourceIsZero(t *testing.T) {
+	tests := []struct {
+		name string
+		src  Source
+		want bool
+	}{
+		{"零值", Source{}, true},
+		{"空路径", FromPath(""), true},
+		{"nil 资源", FromResource(nil), true},
+		{"路径", FromPath("a.svg"), false},
+		{"空字节仍是字节来源", FromBytes("inline", nil), false},
+	}
+	for _, tt := range tests {
+		if got := tt.src.IsZero(); got != tt.want {
+			t.Errorf("%s: IsZero() = %v, 期望 %v", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := NewLoader(nil).Load(FromBytes("inline", nil)); err == nil {
+		t.Error("空字节来源加载时应返回错误")
+	}
+}
+
+func TestSourceKey(t *testing.T) {
+	a := FromBytes("icon.svg", []byte(`<svg id="a"/>`))
+	b := FromBytes("icon.svg", []byte(`<svg id="b"/>`))
+	if a.Key() == b.Key() {
+		t.Error("同名但内容不同的字节来源应有不同的键")
+	}
+	if a.Key() != FromBytes("other.svg", []byte(`<svg id="a"/>`)).Key() {
+		t.Error("内容相同的字节来源应有相同的键")
+	}
+
+	r1 := FromResource(fyne.NewStaticResource("icon.svg", []byte("1")))
+	r2 := FromResource(fyne.NewStaticResource("icon.svg", []byte("2")))
+	if r1.Key() == r2.Key() {
+		t.Error("同名但内容不同的资源应有不同的键")
+	}
+
+	if FromPath("svg/1.svg").Key() != FromPath("./svg/1.svg").Key() {
+		t.Error("等价路径应有相同的键")
+	}
+	if FromPath("svg/1.svg").Key() == FromBytes("svg/1.svg", []byte("x")).Key() {
+		t.Error("路径来源和字节来源的键不应冲突")
+	}
+	if (Source{}).Key() != "" {
+		t.Error("空来源的键应为空")
+	}
+}
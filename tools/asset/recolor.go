@@ -0,0 +1,233 @@
+// recolor.go
+package asset
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+)
+
+// RecolorRule 按选择器给 SVG 元素重新着色
+//
+// Selector 支持：
+//   - "#id"    匹配 id 属性
+//   - ".class" 匹配 class 属性中的某个类名
+//   - "tag"    匹配元素名，例如 "path"
+//   - "*"      匹配所有元素
+//
+// Fill / Stroke 为 nil 时保持原色，因此多色图标可以只改其中一层
+// 原本为 none 或 url(#...) 渐变的 fill / stroke 默认不改，否则描边图标会被填实；
+// Force 为 true 时也一并替换
+type RecolorRule struct {
+	Selector string
+	Fill     color.Color
+	Stroke   color.Color
+	Force    bool
+}
+
+// paint 某个属性最终生效的着色
+type paint struct {
+	color color.Color
+	force bool
+}
+
+// Recolor 按规则改写 SVG 中匹配元素的 fill / stroke，返回新的 SVG 数据
+// 后面的规则覆盖前面的规则；没有规则时原样返回，保留原始多色图稿
+// 只重新生成匹配元素的开始标签，其余内容逐字节保留
+func Recolor(svg []byte, rules ...RecolorRule) ([]byte, error) {
+	if len(rules) == 0 {
+		return svg, nil
+	}
+	for _, r := range rules {
+		if r.Selector == "" {
+			return nil, errors.New("着色规则缺少选择器")
+		}
+	}
+
+	var out bytes.Buffer
+	dec := xml.NewDecoder(bytes.NewReader(svg))
+	last := int64(0)
+	for {
+		start := dec.InputOffset()
+		tok, err := dec.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("解析 SVG 失败: %w", err)
+		}
+		el, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		var fill, stroke paint
+		for _, r := range rules {
+			if matchSelector(r.Selector, el) {
+				if r.Fill != nil {
+					fill = paint{r.Fill, r.Force}
+				}
+				if r.Stroke != nil {
+					stroke = paint{r.Stroke, r.Force}
+				}
+			}
+		}
+		changed := setPaint(&el, "fill", fill)
+		if setPaint(&el, "stroke", stroke) {
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+
+		end := dec.InputOffset()
+		out.Write(svg[last:start])
+		writeStartTag(&out, el, bytes.HasSuffix(svg[start:end], []byte("/>")))
+		last = end
+	}
+	out.Write(svg[last:])
+	return out.Bytes(), nil
+}
+
+func matchSelector(sel string, el xml.StartElement) bool {
+	switch {
+	case sel == "*":
+		return true
+	case strings.HasPrefix(sel, "#"):
+		return attrValue(el, "id") == sel[1:]
+	case strings.HasPrefix(sel, "."):
+		for _, c := range strings.Fields(attrValue(el, "class")) {
+			if c == sel[1:] {
+				return true
+			}
+		}
+		return false
+	default:
+		return el.Name.Local == sel
+	}
+}
+
+func attrValue(el xml.StartElement, name string) string {
+	if i := attrIndex(el, name); i >= 0 {
+		return el.Attr[i].Value
+	}
+	return ""
+}
+
+// attrIndex 返回无前缀属性 name 的下标，不存在时返回 -1
+func attrIndex(el xml.StartElement, name string) int {
+	for i, a := range el.Attr {
+		if a.Name.Space == "" && a.Name.Local == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// setPaint 在元素上设置 fill 或 stroke，返回是否有改动
+// style 中的声明优先级高于属性，所以 style 里有同名声明时直接改 style
+func setPaint(el *xml.StartElement, prop string, p paint) bool {
+	if p.color == nil {
+		return false
+	}
+	value, opacity := paintValue(p.color)
+
+	if i := attrIndex(*el, "style"); i >= 0 {
+		style := el.Attr[i].Value
+		if cur, ok := styleProp(style, prop); ok {
+			if !p.force && keepPaint(cur) {
+				return false
+			}
+			el.Attr[i].Value = setStyleProp(style, prop, value, opacity)
+			return true
+		}
+	}
+
+	if i := attrIndex(*el, prop); i >= 0 && !p.force && keepPaint(el.Attr[i].Value) {
+		return false
+	}
+	setAttr(el, prop, value)
+	setAttr(el, prop+"-opacity", opacity)
+	return true
+}
+
+// keepPaint 判断原有的着色是否默认保留：none 表示不绘制，url(...) 为渐变或图案
+func keepPaint(v string) bool {
+	v = strings.TrimSpace(v)
+	return v == "none" || strings.HasPrefix(v, "url(")
+}
+
+// styleProp 读取 style 中 prop 声明的值
+func styleProp(style, prop string) (string, bool) {
+	for _, d := range strings.Split(style, ";") {
+		name, value, ok := strings.Cut(d, ":")
+		if ok && strings.TrimSpace(name) == prop {
+			return strings.TrimSpace(value), true
+		}
+	}
+	return "", false
+}
+
+// setStyleProp 去掉 style 中原有的 prop 和 prop-opacity 声明，再追加新值
+func setStyleProp(style, prop, value, opacity string) string {
+	var kept []string
+	for _, d := range strings.Split(style, ";") {
+		name, _, _ := strings.Cut(d, ":")
+		switch strings.TrimSpace(name) {
+		case prop, prop + "-opacity":
+			continue
+		}
+		if strings.TrimSpace(d) != "" {
+			kept = append(kept, d)
+		}
+	}
+	kept = append(kept, prop+":"+value, prop+"-opacity:"+opacity)
+	return strings.Join(kept, ";")
+}
+
+// setAttr 替换已有属性的值，不存在时追加到末尾
+func setAttr(el *xml.StartElement, name, value string) {
+	if i := attrIndex(*el, name); i >= 0 {
+		el.Attr[i].Value = value
+		return
+	}
+	el.Attr = append(el.Attr, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+}
+
+// writeStartTag 按解码后的元素重新生成开始标签，保留命名空间前缀
+func writeStartTag(w *bytes.Buffer, el xml.StartElement, selfClosing bool) {
+	w.WriteByte('<')
+	writeName(w, el.Name)
+	for _, a := range el.Attr {
+		w.WriteByte(' ')
+		writeName(w, a.Name)
+		w.WriteString(`="`)
+		xml.EscapeText(w, []byte(a.Value))
+		w.WriteByte('"')
+	}
+	if selfClosing {
+		w.WriteByte('/')
+	}
+	w.WriteByte('>')
+}
+
+// writeName RawToken 不解析命名空间，Space 中保存的是前缀
+func writeName(w *bytes.Buffer, n xml.Name) {
+	if n.Space != "" {
+		w.WriteString(n.Space)
+		w.WriteByte(':')
+	}
+	w.WriteString(n.Local)
+}
+
+// paintValue 把颜色转换为 SVG 的 "#rrggbb" 和不透明度
+func paintValue(c color.Color) (string, string) {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	value := fmt.Sprintf("#%02x%02x%02x", n.R, n.G, n.B)
+	opacity := fmt.Sprintf("%.3g", float64(n.A)/255)
+	return value, opacity
+}
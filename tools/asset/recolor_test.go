@@ -0,0 +1,138 @@
+package asset
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+)
+
+const layeredSVG = `<?xml version="1.0"?>
+<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24">
+  <!-- 背景层 -->
+  <circle id="bg" cx="12" cy="12" r="12" fill="#eeeeee"/>
+  <path class="glyph main" d="M4 4h16" stroke="#000000" fill-rule="evenodd"/>
+  <path class="accent" d="M4 8h16" style="fill:#ff0000;stroke-width:2"/>
+</svg>`
+
+func TestRecolorNoRulesKeepsOriginal(t *testing.T) {
+	out, err := Recolor([]byte(layeredSVG))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != layeredSVG {
+		t.Error("没有规则时应原样返回")
+	}
+}
+
+func TestRecolorSelectors(t *testing.T) {
+	blue := color.RGBA{52, 152, 219, 255}
+	out, err := Recolor([]byte(layeredSVG),
+		RecolorRule{Selector: "#bg", Fill: color.White},
+		RecolorRule{Selector: ".glyph", Stroke: blue},
+		RecolorRule{Selector: ".accent", Fill: color.NRGBA{0, 0, 0, 128}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := string(out)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"按 id 替换 fill 属性", `<circle id="bg" cx="12" cy="12" r="12" fill="#ffffff" fill-opacity="1"/>`},
+		{"按 class 替换 stroke，fill-rule 不受影响", `stroke="#3498db" fill-rule="evenodd" stroke-opacity="1"/>`},
+		{"style 中的声明直接改写", `style="stroke-width:2;fill:#000000;fill-opacity:0.502"`},
+		{"注释保留", `<!-- 背景层 -->`},
+		{"XML 声明保留", `<?xml version="1.0"?>`},
+	}
+	for _, tt := range tests {
+		if !strings.Contains(s, tt.want) {
+			t.Errorf("%s: 输出中没有 %s\n%s", tt.name, tt.want, s)
+		}
+	}
+}
+
+func TestRecolorLaterRuleWins(t *testing.T) {
+	out, err := Recolor([]byte(`<svg><path class="a" d="M0 0"/></svg>`),
+		RecolorRule{Selector: "path", Fill: color.Black},
+		RecolorRule{Selector: ".a", Fill: color.White},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<svg><path class="a" d="M0 0" fill="#ffffff" fill-opacity="1"/></svg>`
+	if string(out) != want {
+		t.Errorf("得到 %s\n期望 %s", out, want)
+	}
+}
+
+func TestRecolorWildcardAndUnmatched(t *testing.T) {
+	svg := `<svg><g><rect width="1" height="1"></rect></g></svg>`
+	out, err := Recolor([]byte(svg), RecolorRule{Selector: "#missing", Fill: color.Black})
+	if err != nil || string(out) != svg {
+		t.Errorf("没有匹配时应原样返回: %s %v", out, err)
+	}
+
+	out, err = Recolor([]byte(svg), RecolorRule{Selector: "*", Stroke: color.Black})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(out), `stroke="#000000"`); n != 3 {
+		t.Errorf("通配符应匹配 3 个元素，实际 %d\n%s", n, out)
+	}
+}
+
+func TestRecolorOnlyTouchesRealAttributes(t *testing.T) {
+	// 其他属性的值里出现 fill= / style= 字样时不能被当作属性改写
+	svg := `<svg><path data-x=' fill="a" style="stroke:red"' title="a &amp; b" xlink:href="#p"/></svg>`
+	out, err := Recolor([]byte(svg), RecolorRule{Selector: "path", Fill: color.Black})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<svg><path data-x=" fill=&#34;a&#34; style=&#34;stroke:red&#34;" title="a &amp; b" xlink:href="#p" fill="#000000" fill-opacity="1"/></svg>`
+	if string(out) != want {
+		t.Errorf("得到 %s\n期望 %s", out, want)
+	}
+}
+
+func TestRecolorKeepsNoneAndURL(t *testing.T) {
+	svg := `<svg><path id="outline" fill="none" stroke="#000000"/>` +
+		`<rect id="grad" style="fill: url(#g)"/></svg>`
+
+	tests := []struct {
+		name  string
+		force bool
+		want  []string
+	}{
+		{"默认保留 none 和 url()", false, []string{
+			`<path id="outline" fill="none" stroke="#ffffff" stroke-opacity="1"/>`,
+			`<rect id="grad" style="fill: url(#g)" stroke="#ffffff"`,
+		}},
+		{"Force 时一并替换", true, []string{
+			`<path id="outline" fill="#ffffff" stroke="#ffffff" fill-opacity="1" stroke-opacity="1"/>`,
+			`<rect id="grad" style="fill:#ffffff;fill-opacity:1" stroke="#ffffff"`,
+		}},
+	}
+	for _, tt := range tests {
+		out, err := Recolor([]byte(svg),
+			RecolorRule{Selector: "*", Fill: color.White, Stroke: color.White, Force: tt.force})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, w := range tt.want {
+			if !strings.Contains(string(out), w) {
+				t.Errorf("%s: 输出中没有 %s\n%s", tt.name, w, out)
+			}
+		}
+	}
+}
+
+func TestRecolorErrors(t *testing.T) {
+	if _, err := Recolor([]byte(`<svg>`), RecolorRule{Selector: "", Fill: color.Black}); err == nil {
+		t.Error("空选择器应返回错误")
+	}
+	if _, err := Recolor([]byte(`<svg><path</svg>`), RecolorRule{Selector: "*", Fill: color.Black}); err == nil {
+		t.Error("无效 SVG 应返回错误")
+	}
+}
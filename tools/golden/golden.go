@@ -0,0 +1,206 @@
+// golden.go
+package golden
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// UpdateEnv 设置该环境变量为 1 时，Assert 会用当前结果覆盖金标准图片
+const UpdateEnv = "GOLDEN_UPDATE"
+
+// Options 比较参数
+type Options struct {
+	// Threshold 单个像素的感知色差阈值（0~1），超过即视为不同像素，0 表示使用 0.1
+	Threshold float64
+	// Exact 为 true 时忽略 Threshold，任何色差都算作不同像素
+	Exact bool
+	// MaxDiffRatio 允许的不同像素比例（0~1），默认 0 表示一个都不允许
+	MaxDiffRatio float64
+	// Dir 金标准图片目录，默认 testdata/golden
+	Dir string
+	// FailDir 失败时写入实际图片和差异图的目录，默认 testdata/failures
+	FailDir string
+}
+
+func (o Options) withDefaults() Options {
+	if o.Exact {
+		o.Threshold = 0
+	} else if o.Threshold <= 0 {
+		o.Threshold = 0.1
+	}
+	if o.Dir == "" {
+		o.Dir = filepath.Join("testdata", "golden")
+	}
+	if o.FailDir == "" {
+		o.FailDir = filepath.Join("testdata", "failures")
+	}
+	return o
+}
+
+// Result 比较结果
+type Result struct {
+	SizeMismatch bool        // 尺寸不同，此时不逐像素比较
+	DiffPixels   int         // 超过阈值的像素数
+	TotalPixels  int         // 像素总数
+	MaxDelta     float64     // 最大感知色差（0~1）
+	Diff         *image.RGBA // 差异图：不同的像素标红，其余为淡化的灰度原图
+}
+
+// DiffRatio 不同像素所占比例
+func (r Result) DiffRatio() float64 {
+	if r.TotalPixels == 0 {
+		return 0
+	}
+	return float64(r.DiffPixels) / float64(r.TotalPixels)
+}
+
+// Compare 逐像素比较两张图片，threshold 为 0 时要求完全相同
+// 色差使用 YIQ 空间的加权距离（与 pixelmatch 相同）
+// 半透明像素分别与白色和黑色背景混合后比较，取较大的色差，所以透明度不同也会被发现
+func Compare(want, got image.Image, threshold float64) Result {
+	wb, gb := want.Bounds(), got.Bounds()
+	if wb.Dx() != gb.Dx() || wb.Dy() != gb.Dy() {
+		return Result{SizeMismatch: true}
+	}
+
+	res := Result{
+		TotalPixels: wb.Dx() * wb.Dy(),
+		Diff:        image.NewRGBA(image.Rect(0, 0, wb.Dx(), wb.Dy())),
+	}
+	for y := 0; y < wb.Dy(); y++ {
+		for x := 0; x < wb.Dx(); x++ {
+			c1 := want.At(wb.Min.X+x, wb.Min.Y+y)
+			c2 := got.At(gb.Min.X+x, gb.Min.Y+y)
+			delta := colorDelta(c1, c2)
+			res.MaxDelta = math.Max(res.MaxDelta, delta)
+			if delta > threshold {
+				res.DiffPixels++
+				res.Diff.Set(x, y, color.RGBA{R: 255, A: 255})
+				continue
+			}
+			// 相同的像素画成淡灰色，方便在差异图里定位
+			r, g, b := blend(c1, 255)
+			lum := uint8(255 - (255-yiqY(r, g, b))*0.1)
+			res.Diff.Set(x, y, color.RGBA{R: lum, G: lum, B: lum, A: 255})
+		}
+	}
+	return res
+}
+
+// maxYIQDelta YIQ 加权距离平方的上限，开方后色差落在 0~1，与 pixelmatch 的阈值含义一致
+const maxYIQDelta = 35215.0
+
+func colorDelta(c1, c2 color.Color) float64 {
+	return math.Max(yiqDelta(c1, c2, 255), yiqDelta(c1, c2, 0))
+}
+
+// yiqDelta 两个颜色与灰度为 bg 的背景混合后的感知色差
+func yiqDelta(c1, c2 color.Color, bg float64) float64 {
+	r1, g1, b1 := blend(c1, bg)
+	r2, g2, b2 := blend(c2, bg)
+	y := yiqY(r1, g1, b1) - yiqY(r2, g2, b2)
+	i := yiqI(r1, g1, b1) - yiqI(r2, g2, b2)
+	q := yiqQ(r1, g1, b1) - yiqQ(r2, g2, b2)
+	return math.Sqrt((0.5053*y*y + 0.299*i*i + 0.1957*q*q) / maxYIQDelta)
+}
+
+// blend 把颜色与灰度为 bg（0~255）的背景混合，返回 0~255 的分量
+func blend(c color.Color, bg float64) (float64, float64, float64) {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	a := float64(n.A) / 255
+	mix := func(v uint8) float64 { return bg + (float64(v)-bg)*a }
+	return mix(n.R), mix(n.G), mix(n.B)
+}
+
+func yiqY(r, g, b float64) float64 { return r*0.29889531 + g*0.58662247 + b*0.11448223 }
+func yiqI(r, g, b float64) float64 { return r*0.59597799 - g*0.27417610 - b*0.32180189 }
+func yiqQ(r, g, b float64) float64 { return r*0.21147017 - g*0.52261711 + b*0.31114694 }
+
+// Assert 把 got 与 Dir/name.png 比较
+// 不一致时把实际图片和差异图写入 FailDir 并标记测试失败
+// 环境变量 GOLDEN_UPDATE=1 时直接覆盖金标准图片
+func Assert(t testing.TB, name string, got image.Image, opts Options) {
+	t.Helper()
+	opts = opts.withDefaults()
+	goldenPath := filepath.Join(opts.Dir, name+".png")
+
+	if os.Getenv(UpdateEnv) == "1" {
+		if err := WritePNG(goldenPath, got); err != nil {
+			t.Fatalf("写入金标准图片失败: %v", err)
+		}
+		return
+	}
+
+	want, err := ReadPNG(goldenPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("金标准图片不存在: %s（设置 %s=1 重新生成）", goldenPath, UpdateEnv)
+	}
+	if err != nil {
+		t.Fatalf("读取金标准图片失败: %v", err)
+	}
+
+	res := Compare(want, got, opts.Threshold)
+	if !res.SizeMismatch && res.DiffRatio() <= opts.MaxDiffRatio {
+		return
+	}
+
+	gotPath := filepath.Join(opts.FailDir, name+"_got.png")
+	if err := WritePNG(gotPath, got); err != nil {
+		t.Errorf("写入实际图片失败: %v", err)
+	}
+	if res.SizeMismatch {
+		t.Fatalf("%s: 尺寸不同，期望 %v，实际 %v（实际图片: %s）",
+			name, want.Bounds().Size(), got.Bounds().Size(), gotPath)
+	}
+	diffPath := filepath.Join(opts.FailDir, name+"_diff.png")
+	if err := WritePNG(diffPath, res.Diff); err != nil {
+		t.Errorf("写入差异图失败: %v", err)
+	}
+	t.Fatalf("%s: %d/%d 个像素不同（%.2f%%，允许 %.2f%%），最大色差 %.3f（差异图: %s）",
+		name, res.DiffPixels, res.TotalPixels, res.DiffRatio()*100, opts.MaxDiffRatio*100,
+		res.MaxDelta, diffPath)
+}
+
+// ReadPNG 读取 PNG 图片
+func ReadPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("解码 %s 失败: %w", path, err)
+	}
+	return img, nil
+}
+
+// WritePNG 写入 PNG 图片，目录不存在时自动创建
+func WritePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// 统一转为 NRGBA，避免不同的图片类型编码出不同的文件
+	n := image.NewNRGBA(image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy()))
+	draw.Draw(n, n.Bounds(), img, img.Bounds().Min, draw.Src)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, n); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
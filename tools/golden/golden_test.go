@@ -0,0 +1,193 @@
+package golden
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func solid(w, h int, c color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestCompareTolerance(t *testing.T) {
+	base := color.RGBA{52, 152, 219, 255}
+	tests := []struct {
+		name      string
+		other     color.Color
+		threshold float64
+		wantDiff  int
+	}{
+		{"完全相同", base, 0.1, 0},
+		{"轻微抗锯齿差异在阈值内", color.RGBA{54, 150, 221, 255}, 0.1, 0},
+		{"明显不同的颜色", color.RGBA{231, 76, 60, 255}, 0.1, 4},
+		{"阈值更严格时轻微差异也算", color.RGBA{54, 150, 221, 255}, 0.00001, 4},
+		{"与白色不同", color.White, 0.1, 4},
+		{"阈值为 0 时要求完全相同", color.RGBA{52, 152, 220, 255}, 0, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Compare(solid(2, 2, base), solid(2, 2, tt.other), tt.threshold)
+			if res.DiffPixels != tt.wantDiff {
+				t.Errorf("DiffPixels = %d, 期望 %d（MaxDelta %.5f）", res.DiffPixels, tt.wantDiff, res.MaxDelta)
+			}
+		})
+	}
+
+	// 分别在白色和黑色背景上比较，透明与不透明的白色应视为不同
+	alpha := []struct {
+		name     string
+		c1, c2   color.Color
+		wantDiff int
+	}{
+		{"透明与白色不同", color.Transparent, color.White, 1},
+		{"透明与黑色不同", color.Transparent, color.Black, 1},
+		{"半透明与不透明不同", color.NRGBA{52, 152, 219, 128}, base, 1},
+		{"完全透明时颜色分量无关", color.NRGBA{255, 0, 0, 0}, color.Transparent, 0},
+	}
+	for _, tt := range alpha {
+		if res := Compare(solid(1, 1, tt.c1), solid(1, 1, tt.c2), 0.1); res.DiffPixels != tt.wantDiff {
+			t.Errorf("%s: DiffPixels = %d, 期望 %d（MaxDelta %.3f）", tt.name, res.DiffPixels, tt.wantDiff, res.MaxDelta)
+		}
+	}
+	if res := Compare(solid(1, 1, color.Black), solid(1, 1, color.White), 0.1); res.MaxDelta < 0.9 {
+		t.Errorf("黑白色差应接近 1，实际 %v", res.MaxDelta)
+	}
+}
+
+func TestCompareDiffImage(t *testing.T) {
+	want := solid(3, 1, color.White)
+	got := solid(3, 1, color.White)
+	got.Set(1, 0, color.Black)
+
+	res := Compare(want, got, 0.1)
+	if res.DiffPixels != 1 || res.DiffRatio() != 1.0/3 {
+		t.Fatalf("结果错误: %+v", res)
+	}
+	if c := res.Diff.RGBAAt(1, 0); c != (color.RGBA{R: 255, A: 255}) {
+		t.Errorf("不同的像素应标红，实际 %v", c)
+	}
+	if c := res.Diff.RGBAAt(0, 0); c.R != c.G || c.R < 200 {
+		t.Errorf("相同的像素应为淡灰色，实际 %v", c)
+	}
+}
+
+func TestCompareSizeMismatch(t *testing.T) {
+	if res := Compare(solid(2, 2, color.White), solid(3, 2, color.White), 0.1); !res.SizeMismatch {
+		t.Error("尺寸不同应返回 SizeMismatch")
+	}
+	// 不同的 Bounds 起点、相同尺寸应正常比较
+	sub := solid(4, 4, color.White).SubImage(image.Rect(2, 2, 4, 4))
+	if res := Compare(solid(2, 2, color.White), sub, 0.1); res.SizeMismatch || res.DiffPixels != 0 {
+		t.Errorf("子图比较错误: %+v", res)
+	}
+}
+
+// fakeTB 记录 Assert 报告的失败
+type fakeTB struct {
+	testing.TB
+	failures []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) Fatalf(format string, args ...any) {
+	f.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+// runAssert 在独立 goroutine 中执行 Assert，使 Fatalf 的 Goexit 不影响当前测试
+func runAssert(name string, got image.Image, opts Options) []string {
+	f := &fakeTB{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Assert(f, name, got, opts)
+	}()
+	<-done
+	return f.failures
+}
+
+func TestAssert(t *testing.T) {
+	dir := t.TempDir()
+	opts := Options{Dir: filepath.Join(dir, "golden"), FailDir: filepath.Join(dir, "failures")}
+
+	if failures := runAssert("toggle", solid(4, 4, color.White), opts); len(failures) != 1 ||
+		!strings.Contains(failures[0], "不存在") {
+		t.Fatalf("缺少金标准图片时应失败: %v", failures)
+	}
+
+	t.Setenv(UpdateEnv, "1")
+	if failures := runAssert("toggle", solid(4, 4, color.White), opts); len(failures) != 0 {
+		t.Fatalf("更新模式不应失败: %v", failures)
+	}
+	t.Setenv(UpdateEnv, "")
+
+	if failures := runAssert("toggle", solid(4, 4, color.White), opts); len(failures) != 0 {
+		t.Errorf("相同图片不应失败: %v", failures)
+	}
+
+	changed := solid(4, 4, color.White)
+	changed.Set(0, 0, color.Black)
+	if failures := runAssert("toggle", changed, opts); len(failures) != 1 {
+		t.Errorf("有差异时应失败: %v", failures)
+	}
+	for _, name := range []string{"toggle_got.png", "toggle_diff.png"} {
+		if _, err := os.Stat(filepath.Join(opts.FailDir, name)); err != nil {
+			t.Errorf("失败时应写出 %s: %v", name, err)
+		}
+	}
+
+	// 允许 1/16 的像素不同
+	opts.MaxDiffRatio = 1.0 / 16
+	if failures := runAssert("toggle", changed, opts); len(failures) != 0 {
+		t.Errorf("差异在允许比例内不应失败: %v", failures)
+	}
+
+	// 轻微差异在默认阈值内，Exact 时则算作不同
+	slight := solid(4, 4, color.White)
+	slight.Set(0, 0, color.RGBA{254, 254, 254, 255})
+	opts.MaxDiffRatio = 0
+	if failures := runAssert("toggle", slight, opts); len(failures) != 0 {
+		t.Errorf("默认阈值下轻微差异不应失败: %v", failures)
+	}
+	opts.Exact = true
+	if failures := runAssert("toggle", slight, opts); len(failures) != 1 {
+		t.Errorf("Exact 时轻微差异应失败: %v", failures)
+	}
+
+	if failures := runAssert("toggle", solid(2, 2, color.White), opts); len(failures) != 1 ||
+		!strings.Contains(failures[0], "尺寸不同") {
+		t.Errorf("尺寸不同时应失败: %v", failures)
+	}
+}
+
+func TestPNGRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "img.png")
+	src := solid(3, 2, color.NRGBA{10, 20, 30, 128})
+	if err := WritePNG(path, src); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadPNG(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := Compare(src, got, 0.0001); res.SizeMismatch || res.DiffPixels != 0 {
+		t.Errorf("读写后图片不一致: %+v", res)
+	}
+}
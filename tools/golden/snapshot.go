@@ -0,0 +1,32 @@
+// snapshot.go
+package golden
+
+import (
+	"image"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+// Snapshot 不打开窗口，把 obj 以逻辑尺寸 size、缩放 scale 渲染为图片
+// 返回的图片为 size*scale 像素，背景为测试主题的背景色；size 为零时使用 obj.MinSize()
+//
+// 使用 Fyne 的软件渲染器，不依赖 OpenGL 和 cgo，可以在 CI 中运行。
+// 导入本包会安装 Fyne 的测试 App（与 fyne.io/fyne/v2/test 相同），只应在测试中使用。
+// 控件的状态（选中、动画进度等）需要在调用前设置好，例如用 anim.ManualClock 把动画推进到指定帧。
+func Snapshot(obj fyne.CanvasObject, size fyne.Size, scale float32) image.Image {
+	if size.IsZero() {
+		size = obj.MinSize()
+	}
+	if scale <= 0 {
+		scale = 1
+	}
+
+	w := test.NewWindow(obj)
+	defer w.Close()
+	c := w.Canvas().(test.WindowlessCanvas)
+	c.SetPadded(false)
+	c.SetScale(scale)
+	c.Resize(size)
+	return c.Capture()
+}
@@ -0,0 +1,57 @@
+package golden
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+)
+
+func TestSnapshot(t *testing.T) {
+	red := color.NRGBA{R: 255, A: 255}
+	rect := canvas.NewRectangle(red)
+	rect.SetMinSize(fyne.NewSize(10, 6))
+
+	tests := []struct {
+		name  string
+		size  fyne.Size
+		scale float32
+		wantW int
+		wantH int
+	}{
+		{"零尺寸使用 MinSize", fyne.Size{}, 1, 10, 6},
+		{"指定尺寸", fyne.NewSize(20, 10), 1, 20, 10},
+		{"2 倍缩放", fyne.NewSize(20, 10), 2, 40, 20},
+		{"1.5 倍缩放", fyne.NewSize(20, 10), 1.5, 30, 15},
+	}
+	for _, tt := range tests {
+		img := Snapshot(rect, tt.size, tt.scale)
+		b := img.Bounds()
+		if b.Dx() != tt.wantW || b.Dy() != tt.wantH {
+			t.Errorf("%s: 尺寸 %v, 期望 %dx%d", tt.name, b.Size(), tt.wantW, tt.wantH)
+			continue
+		}
+		if c := color.NRGBAModel.Convert(img.At(b.Dx()/2, b.Dy()/2)); c != red {
+			t.Errorf("%s: 中心像素 %v, 期望红色", tt.name, c)
+		}
+	}
+}
+
+func TestSnapshotIsDeterministic(t *testing.T) {
+	// 左红右蓝，两次渲染逐像素完全相同，且左右两半的颜色正确
+	blue := color.NRGBA{B: 255, A: 255}
+	content := container.New(layout.NewGridLayout(2),
+		canvas.NewRectangle(color.NRGBA{R: 255, A: 255}), canvas.NewRectangle(blue))
+
+	first := Snapshot(content, fyne.NewSize(40, 20), 1)
+	second := Snapshot(content, fyne.NewSize(40, 20), 1)
+	if res := Compare(first, second, 0); res.DiffPixels != 0 || res.SizeMismatch {
+		t.Errorf("两次渲染结果不同: %d 个像素", res.DiffPixels)
+	}
+	if c := color.NRGBAModel.Convert(first.At(35, 10)); c != blue {
+		t.Errorf("右半部分应为蓝色，实际 %v", c)
+	}
+}
@@ -0,0 +1,100 @@
+// backing.go
+package raster
+
+import (
+	"math"
+
+	"fyne.io/fyne/v2"
+)
+
+// Limits 后备图像（gg 光栅化的目标图像）的尺寸上限，0 表示不限制该项
+type Limits struct {
+	MaxWidth  int // 最大像素宽度
+	MaxHeight int // 最大像素高度
+	MaxPixels int // 最大像素总数
+}
+
+// DefaultLimits 默认上限：单边不超过 4096 像素、总共不超过 16M 像素（约 64MB RGBA）
+var DefaultLimits = Limits{MaxWidth: 4096, MaxHeight: 4096, MaxPixels: 4096 * 4096}
+
+// BackingSize 计算逻辑尺寸为 size 的控件在缩放 scale 下的后备图像像素尺寸
+// 像素尺寸按 Fyne 的规则向上取整；超出 lim 时等比缩小，返回实际使用的缩放倍数，
+// 绘制时用它代替 scale，图像交给 canvas.Image 后仍会铺满控件
+func BackingSize(size fyne.Size, scale float32, lim Limits) (w, h int, effective float32) {
+	if scale <= 0 {
+		scale = 1
+	}
+	if size.Width <= 0 || size.Height <= 0 {
+		return 0, 0, scale
+	}
+
+	lw, lh := float64(size.Width), float64(size.Height)
+	s := float64(scale)
+	fw, fh := lw*s, lh*s
+
+	// 按最严格的一项限制等比缩小
+	f := 1.0
+	if lim.MaxWidth > 0 && fw > float64(lim.MaxWidth) {
+		f = math.Min(f, float64(lim.MaxWidth)/fw)
+	}
+	if lim.MaxHeight > 0 && fh > float64(lim.MaxHeight) {
+		f = math.Min(f, float64(lim.MaxHeight)/fh)
+	}
+	if lim.MaxPixels > 0 && fw*fh > float64(lim.MaxPixels) {
+		f = math.Min(f, math.Sqrt(float64(lim.MaxPixels)/(fw*fh)))
+	}
+	if f == 1 {
+		return int(math.Ceil(fw)), int(math.Ceil(fh)), scale
+	}
+
+	// 缩小后向下取整，保证不超过上限
+	s *= f
+	w = max(int(math.Floor(lw*s)), 1)
+	h = max(int(math.Floor(lh*s)), 1)
+	return w, h, float32(s)
+}
+
+// ScaleFor 返回 obj 所在画布的缩放倍数，obj 还没有显示在窗口中时返回 1
+func ScaleFor(obj fyne.CanvasObject) float32 {
+	app := fyne.CurrentApp()
+	if app == nil {
+		return 1
+	}
+	c := app.Driver().CanvasForObject(obj)
+	if c == nil || c.Scale() <= 0 {
+		return 1
+	}
+	return c.Scale()
+}
+
+// Backing 记录控件上一次光栅化使用的像素尺寸
+// 渲染器在 Layout / Refresh 中调用 Update，窗口移到缩放不同的屏幕或控件尺寸变化时重新光栅化
+type Backing struct {
+	// Limits 尺寸上限，零值表示使用 DefaultLimits
+	Limits Limits
+
+	w, h  int
+	scale float32
+}
+
+// Update 按新的逻辑尺寸和缩放计算像素尺寸，changed 表示与上一次不同、需要重新光栅化
+func (b *Backing) Update(size fyne.Size, scale float32) (w, h int, effective float32, changed bool) {
+	lim := b.Limits
+	if lim == (Limits{}) {
+		lim = DefaultLimits
+	}
+	w, h, effective = BackingSize(size, scale, lim)
+	changed = w != b.w || h != b.h || effective != b.scale
+	b.w, b.h, b.scale = w, h, effective
+	return w, h, effective, changed
+}
+
+// Size 返回上一次 Update 得到的像素尺寸和缩放倍数
+func (b *Backing) Size() (w, h int, scale float32) {
+	return b.w, b.h, b.scale
+}
+
+// Invalidate 清除记录，下一次 Update 一定返回 changed
+func (b *Backing) Invalidate() {
+	b.w, b.h, b.scale = 0, 0, 0
+}
@@ -0,0 +1,96 @@
+package raster
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestBackingSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      fyne.Size
+		scale     float32
+		lim       Limits
+		wantW     int
+		wantH     int
+		wantScale float32
+	}{
+		{"1 倍", fyne.NewSize(220, 56), 1, DefaultLimits, 220, 56, 1},
+		{"150% 向上取整", fyne.NewSize(25, 25), 1.5, DefaultLimits, 38, 38, 1.5},
+		{"200%", fyne.NewSize(220, 56), 2, DefaultLimits, 440, 112, 2},
+		{"无效缩放按 1 计算", fyne.NewSize(10, 10), 0, DefaultLimits, 10, 10, 1},
+		{"零尺寸", fyne.NewSize(0, 56), 2, DefaultLimits, 0, 0, 2},
+		{"不限制", fyne.NewSize(5000, 10), 2, Limits{}, 10000, 20, 2},
+		{"宽度超限时等比缩小", fyne.NewSize(1000, 100), 2, Limits{MaxWidth: 1000}, 1000, 100, 1},
+		{"高度超限时等比缩小", fyne.NewSize(100, 1000), 2, Limits{MaxHeight: 500}, 50, 500, 0.5},
+		{"像素总数超限", fyne.NewSize(200, 200), 2, Limits{MaxPixels: 100 * 100}, 100, 100, 0.5},
+	}
+	for _, tt := range tests {
+		w, h, s := BackingSize(tt.size, tt.scale, tt.lim)
+		if w != tt.wantW || h != tt.wantH || s != tt.wantScale {
+			t.Errorf("%s: 得到 %dx%d@%v, 期望 %dx%d@%v", tt.name, w, h, s, tt.wantW, tt.wantH, tt.wantScale)
+		}
+	}
+}
+
+func TestBackingSizeRespectsLimits(t *testing.T) {
+	lim := Limits{MaxWidth: 300, MaxHeight: 200, MaxPixels: 40000}
+	for _, size := range []fyne.Size{
+		fyne.NewSize(333, 77), fyne.NewSize(77, 333), fyne.NewSize(1234, 1234), fyne.NewSize(299.5, 1),
+	} {
+		for _, scale := range []float32{1, 1.25, 1.5, 2, 3} {
+			w, h, _ := BackingSize(size, scale, lim)
+			if w > lim.MaxWidth || h > lim.MaxHeight || w*h > lim.MaxPixels || w < 1 || h < 1 {
+				t.Errorf("%v@%v: %dx%d 超出上限", size, scale, w, h)
+			}
+		}
+	}
+}
+
+func TestBackingUpdate(t *testing.T) {
+	var b Backing
+	steps := []struct {
+		name        string
+		size        fyne.Size
+		scale       float32
+		wantChanged bool
+	}{
+		{"首次", fyne.NewSize(100, 40), 1, true},
+		{"尺寸和缩放都没变", fyne.NewSize(100, 40), 1, false},
+		{"窗口移到 200% 的屏幕", fyne.NewSize(100, 40), 2, true},
+		{"逻辑尺寸变化", fyne.NewSize(120, 40), 2, true},
+		{"取整后像素相同", fyne.NewSize(119.8, 40), 2, false},
+	}
+	for _, st := range steps {
+		if _, _, _, changed := b.Update(st.size, st.scale); changed != st.wantChanged {
+			t.Errorf("%s: changed = %v, 期望 %v", st.name, changed, st.wantChanged)
+		}
+	}
+	if w, h, s := b.Size(); w != 240 || h != 80 || s != 2 {
+		t.Errorf("Size() = %dx%d@%v", w, h, s)
+	}
+
+	b.Invalidate()
+	if _, _, _, changed := b.Update(fyne.NewSize(120, 40), 2); !changed {
+		t.Error("Invalidate 后应重新光栅化")
+	}
+
+	// 零值 Limits 使用 DefaultLimits
+	if w, _, _, _ := b.Update(fyne.NewSize(10000, 10), 1); w != DefaultLimits.MaxWidth {
+		t.Errorf("应使用默认宽度上限，实际 %d", w)
+	}
+}
+
+func TestScaleFor(t *testing.T) {
+	rect := canvas.NewRectangle(nil)
+	w := test.NewWindow(rect)
+	defer w.Close()
+
+	w.Canvas().(test.WindowlessCanvas).SetScale(1.5)
+	if s := ScaleFor(rect); s != 1.5 {
+		t.Errorf("ScaleFor = %v, 期望 1.5", s)
+	}
+}
@@ -0,0 +1,157 @@
+// frame.go
+package raster
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// StateHash 控件视觉状态的摘要（FNV-1a），相同摘要表示画出来的图像相同
+// 值类型，可以链式调用：NewStateHash().Bool(checked).Float(progress).Color(c)
+type StateHash uint64
+
+const (
+	fnvOffset = 14695981039346656037
+	fnvPrime  = 1099511628211
+)
+
+// NewStateHash 创建空摘要
+func NewStateHash() StateHash { return fnvOffset }
+
+// Uint64 写入一个整数
+func (h StateHash) Uint64(v uint64) StateHash {
+	for i := 0; i < 8; i++ {
+		h ^= StateHash(v & 0xff)
+		h *= fnvPrime
+		v >>= 8
+	}
+	return h
+}
+
+// Int 写入一个整数
+func (h StateHash) Int(v int) StateHash { return h.Uint64(uint64(v)) }
+
+// Float 写入一个浮点数，动画进度等连续值应先量化，否则每帧都会不同
+func (h StateHash) Float(v float64) StateHash { return h.Uint64(math.Float64bits(v)) }
+
+// Bool 写入一个布尔值
+func (h StateHash) Bool(v bool) StateHash {
+	if v {
+		return h.Uint64(1)
+	}
+	return h.Uint64(0)
+}
+
+// Text 写入一个字符串
+func (h StateHash) Text(s string) StateHash {
+	h = h.Int(len(s))
+	for i := 0; i < len(s); i++ {
+		h ^= StateHash(s[i])
+		h *= fnvPrime
+	}
+	return h
+}
+
+// Color 写入一个颜色，nil 与透明色不同
+func (h StateHash) Color(c color.Color) StateHash {
+	if c == nil {
+		return h.Bool(false)
+	}
+	r, g, b, a := c.RGBA()
+	return h.Bool(true).Uint64(uint64(r)<<48 | uint64(g)<<32 | uint64(b)<<16 | uint64(a))
+}
+
+// FrameStats 控件光栅化统计
+type FrameStats struct {
+	Full    uint64 // 整张重绘的次数
+	Partial uint64 // 只重绘脏区域的次数
+	Skipped uint64 // 状态没变、直接复用上一帧的次数
+}
+
+// Frame 控件的后备图像：从缓冲池复用图像，视觉状态没变时跳过光栅化，只重绘脏区域
+//
+// 典型用法（渲染器的 Refresh 中）：
+//
+//	img := f.Render(w, h, state, func(dst *image.RGBA, clip image.Rectangle) { ... })
+//	r.image.Image = img
+//	r.image.Refresh()
+//
+// Render 原地重绘同一张图像，所以每次都要调用 canvas.Image.Refresh 让 Fyne 更新纹理
+type Frame struct {
+	// Pool 缓冲池，nil 时使用 SharedPool
+	Pool *Pool
+
+	img   *image.RGBA
+	state StateHash
+	valid bool
+	dirty image.Rectangle
+	stats FrameStats
+}
+
+// Invalidate 标记区域需要重绘，用于状态摘要之外的变化（例如粒子移动）
+func (f *Frame) Invalidate(r image.Rectangle) {
+	f.dirty = f.dirty.Union(r)
+}
+
+// InvalidateAll 下一次 Render 整张重绘
+func (f *Frame) InvalidateAll() {
+	f.valid = false
+}
+
+// Render 返回 w×h 的图像
+//   - 尺寸变化或状态摘要不同时整张清空并重绘，paint 的 clip 为整张图像
+//   - 状态相同但有脏区域时只清空并重绘脏区域，dst 为 clip 对应的子图像（坐标与整张图像相同），
+//     clip 以外的像素不会被改动
+//   - 状态相同且没有脏区域时直接返回上一帧，不调用 paint
+func (f *Frame) Render(w, h int, state StateHash, paint func(dst *image.RGBA, clip image.Rectangle)) *image.RGBA {
+	pool := f.Pool
+	if pool == nil {
+		pool = SharedPool
+	}
+	full := !f.valid || state != f.state
+	if f.img == nil || f.img.Bounds().Dx() != w || f.img.Bounds().Dy() != h {
+		// 缓冲池取出的图像已经是透明的
+		pool.Put(f.img)
+		f.img = pool.Get(w, h)
+		full = true
+	} else if full {
+		clear(f.img.Pix)
+	}
+
+	switch {
+	case full:
+		paint(f.img, f.img.Bounds())
+		f.stats.Full++
+	case !f.dirty.Intersect(f.img.Bounds()).Empty():
+		clip := f.dirty.Intersect(f.img.Bounds())
+		clearRect(f.img, clip)
+		paint(f.img.SubImage(clip).(*image.RGBA), clip)
+		f.stats.Partial++
+	default:
+		f.stats.Skipped++
+	}
+	f.state, f.valid, f.dirty = state, true, image.Rectangle{}
+	return f.img
+}
+
+// Stats 返回光栅化统计
+func (f *Frame) Stats() FrameStats { return f.stats }
+
+// Release 把图像归还缓冲池，控件销毁时调用
+func (f *Frame) Release() {
+	pool := f.Pool
+	if pool == nil {
+		pool = SharedPool
+	}
+	pool.Put(f.img)
+	f.img, f.valid, f.dirty = nil, false, image.Rectangle{}
+}
+
+// clearRect 把 img 中的 r 区域清为透明
+func clearRect(img *image.RGBA, r image.Rectangle) {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		i := img.PixOffset(r.Min.X, y)
+		clear(img.Pix[i : i+r.Dx()*4])
+	}
+}
@@ -0,0 +1,160 @@
+package raster
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+// fill 用纯色填满 dst 并记录调用次数
+func fill(calls *int, c color.Color) func(*image.RGBA, image.Rectangle) {
+	return func(dst *image.RGBA, clip image.Rectangle) {
+		*calls++
+		draw.Draw(dst, clip, image.NewUniform(c), image.Point{}, draw.Src)
+	}
+}
+
+func TestFrameSkipsUnchangedState(t *testing.T) {
+	f := Frame{Pool: NewPool(2)}
+	calls := 0
+	checked := NewStateHash().Bool(true).Float(0.5)
+
+	first := f.Render(40, 20, checked, fill(&calls, color.White))
+	second := f.Render(40, 20, NewStateHash().Bool(true).Float(0.5), fill(&calls, color.White))
+	if calls != 1 || first != second {
+		t.Errorf("状态相同应跳过光栅化，paint 调用 %d 次", calls)
+	}
+
+	f.Render(40, 20, NewStateHash().Bool(false).Float(0.5), fill(&calls, color.White))
+	if calls != 2 {
+		t.Error("状态变化后应重绘")
+	}
+	f.InvalidateAll()
+	f.Render(40, 20, NewStateHash().Bool(false).Float(0.5), fill(&calls, color.White))
+	if calls != 3 {
+		t.Error("InvalidateAll 后应重绘")
+	}
+
+	want := FrameStats{Full: 3, Skipped: 1}
+	if s := f.Stats(); s != want {
+		t.Errorf("统计 = %+v, 期望 %+v", s, want)
+	}
+}
+
+func TestFrameDirtyRegion(t *testing.T) {
+	f := Frame{Pool: NewPool(2)}
+	calls := 0
+	state := NewStateHash().Text("粒子按钮")
+	img := f.Render(10, 10, state, fill(&calls, color.White))
+
+	// 只重绘脏区域，区域外保持上一帧的内容
+	red := color.RGBA{R: 255, A: 255}
+	f.Invalidate(image.Rect(2, 2, 4, 4))
+	f.Invalidate(image.Rect(8, 8, 20, 20)) // 超出图像的部分被裁掉
+	var clips []image.Rectangle
+	f.Render(10, 10, state, func(dst *image.RGBA, clip image.Rectangle) {
+		clips = append(clips, clip)
+		// 故意画满整张图，子图像保证 clip 以外不受影响
+		draw.Draw(dst, image.Rect(0, 0, 10, 10), image.NewUniform(red), image.Point{}, draw.Src)
+	})
+	if len(clips) != 1 || clips[0] != image.Rect(2, 2, 10, 10) {
+		t.Fatalf("脏区域 = %v", clips)
+	}
+	if img.RGBAAt(5, 5) != red || img.RGBAAt(1, 1) != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("局部重绘结果错误: %v %v", img.RGBAAt(5, 5), img.RGBAAt(1, 1))
+	}
+	if img.RGBAAt(1, 5) != (color.RGBA{255, 255, 255, 255}) {
+		t.Error("脏区域外的像素不应改变")
+	}
+
+	// 状态变化时脏区域被整张重绘取代
+	f.Invalidate(image.Rect(0, 0, 1, 1))
+	f.Render(10, 10, state.Int(1), fill(&calls, color.White))
+	if s := f.Stats(); s.Full != 2 || s.Partial != 1 {
+		t.Errorf("统计错误: %+v", s)
+	}
+}
+
+func TestFrameResizeUsesPool(t *testing.T) {
+	pool := NewPool(2)
+	f := Frame{Pool: pool}
+	calls := 0
+	state := NewStateHash()
+
+	small := f.Render(10, 10, state, fill(&calls, color.White))
+	f.Render(20, 10, state, fill(&calls, color.White))
+	if calls != 2 {
+		t.Error("尺寸变化时应重绘")
+	}
+	// 变回原尺寸时复用之前归还的图像，并且是干净的
+	again := f.Render(10, 10, state, func(dst *image.RGBA, clip image.Rectangle) {
+		if dst.RGBAAt(0, 0) != (color.RGBA{}) {
+			t.Error("复用的图像应先清空")
+		}
+	})
+	if again != small {
+		t.Error("应从缓冲池复用同尺寸的图像")
+	}
+
+	f.Release()
+	if s := pool.Stats(); s.Puts != 3 {
+		t.Errorf("Release 后应归还图像: %+v", s)
+	}
+}
+
+func TestStateHash(t *testing.T) {
+	base := NewStateHash().Bool(true).Float(0.5).Color(color.White).Text("开")
+	tests := []struct {
+		name string
+		h    StateHash
+	}{
+		{"布尔值不同", NewStateHash().Bool(false).Float(0.5).Color(color.White).Text("开")},
+		{"进度不同", NewStateHash().Bool(true).Float(0.51).Color(color.White).Text("开")},
+		{"颜色不同", NewStateHash().Bool(true).Float(0.5).Color(color.Black).Text("开")},
+		{"颜色为 nil", NewStateHash().Bool(true).Float(0.5).Color(nil).Text("开")},
+		{"文字不同", NewStateHash().Bool(true).Float(0.5).Color(color.White).Text("关")},
+		{"顺序不同", NewStateHash().Float(0.5).Bool(true).Color(color.White).Text("开")},
+	}
+	for _, tt := range tests {
+		if tt.h == base {
+			t.Errorf("%s: 摘要不应相同", tt.name)
+		}
+	}
+	if NewStateHash().Color(nil) == NewStateHash().Color(color.Transparent) {
+		t.Error("nil 颜色与透明色的摘要不应相同")
+	}
+	if NewStateHash().Text("ab").Text("c") == NewStateHash().Text("a").Text("bc") {
+		t.Error("字符串边界不同时摘要不应相同")
+	}
+}
+
+func TestFrameSkipAllocs(t *testing.T) {
+	f := Frame{Pool: NewPool(1)}
+	state := NewStateHash().Bool(true)
+	paint := func(*image.RGBA, image.Rectangle) {}
+	f.Render(64, 64, state, paint)
+
+	if n := testing.AllocsPerRun(100, func() { f.Render(64, 64, state, paint) }); n != 0 {
+		t.Errorf("状态没变时每帧分配 %v 次，期望 0", n)
+	}
+}
+
+// 每帧都分配新图像与使用 Frame 的对比，go test -bench=Frame -benchmem
+func BenchmarkFrameNewImage(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		img := image.NewRGBA(image.Rect(0, 0, 220, 56))
+		img.Pix[i%len(img.Pix)] = 1
+	}
+}
+
+func BenchmarkFrameAnimating(b *testing.B) {
+	f := Frame{Pool: NewPool(1)}
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		f.Render(220, 56, NewStateHash().Int(i), func(dst *image.RGBA, _ image.Rectangle) {
+			dst.Pix[i%len(dst.Pix)] = 1
+		})
+	}
+}
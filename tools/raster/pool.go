@@ -0,0 +1,76 @@
+// pool.go
+package raster
+
+import (
+	"image"
+	"sync"
+)
+
+// PoolStats 缓冲池统计，供 benchmark 页面显示节省的分配
+type PoolStats struct {
+	Gets  uint64 // Get 调用次数
+	News  uint64 // 池中没有可用图像、新分配的次数
+	Puts  uint64 // 归还次数
+	Drops uint64 // 同尺寸空闲图像已满、直接丢弃的次数
+}
+
+// Reused 复用的次数
+func (s PoolStats) Reused() uint64 { return s.Gets - s.News }
+
+// Pool 按尺寸复用 image.RGBA 的缓冲池，可在多个 goroutine 中使用
+// 与 sync.Pool 不同，空闲图像不会被 GC 清空，动画期间尺寸固定的控件每帧都能命中
+type Pool struct {
+	mu         sync.Mutex
+	maxPerSize int
+	free       map[image.Point][]*image.RGBA
+	stats      PoolStats
+}
+
+// NewPool 创建缓冲池，maxPerSize 为每种尺寸最多保留的空闲图像数量
+func NewPool(maxPerSize int) *Pool {
+	return &Pool{maxPerSize: max(maxPerSize, 1), free: make(map[image.Point][]*image.RGBA)}
+}
+
+// SharedPool 进程级共享缓冲池
+var SharedPool = NewPool(4)
+
+// Get 取出一张 w×h 的透明图像
+func (p *Pool) Get(w, h int) *image.RGBA {
+	size := image.Pt(w, h)
+	p.mu.Lock()
+	p.stats.Gets++
+	if list := p.free[size]; len(list) > 0 {
+		img := list[len(list)-1]
+		p.free[size] = list[:len(list)-1]
+		p.mu.Unlock()
+		clear(img.Pix)
+		return img
+	}
+	p.stats.News++
+	p.mu.Unlock()
+	return image.NewRGBA(image.Rect(0, 0, w, h))
+}
+
+// Put 归还 Get 取出的图像，归还后调用方不能再使用它（包括已经交给 canvas.Image 的引用）
+func (p *Pool) Put(img *image.RGBA) {
+	if img == nil {
+		return
+	}
+	size := img.Bounds().Size()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.stats.Puts++
+	if len(p.free[size]) >= p.maxPerSize {
+		p.stats.Drops++
+		return
+	}
+	p.free[size] = append(p.free[size], img)
+}
+
+// Stats 返回当前统计信息
+func (p *Pool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats
+}
@@ -0,0 +1,57 @@
+package raster
+
+import (
+	"image/color"
+	"sync"
+	"testing"
+)
+
+func TestPoolReuse(t *testing.T) {
+	p := NewPool(1)
+	a := p.Get(20, 10)
+	a.Set(1, 1, color.White)
+	p.Put(a)
+
+	b := p.Get(20, 10)
+	if b != a {
+		t.Fatal("同尺寸应复用归还的图像")
+	}
+	if b.RGBAAt(1, 1) != (color.RGBA{}) {
+		t.Error("复用的图像应被清为透明")
+	}
+	if c := p.Get(10, 20); c == a || c.Bounds().Dx() != 10 || c.Bounds().Dy() != 20 {
+		t.Error("不同尺寸应分配新图像")
+	}
+
+	p.Put(b)
+	p.Put(p.Get(30, 30))
+	p.Put(p.Get(20, 10))
+	p.Put(NewPool(1).Get(20, 10)) // 20x10 的空闲图像已满
+
+	want := PoolStats{Gets: 5, News: 3, Puts: 5, Drops: 1}
+	if s := p.Stats(); s != want {
+		t.Errorf("统计 = %+v, 期望 %+v", s, want)
+	}
+	if r := p.Stats().Reused(); r != 2 {
+		t.Errorf("Reused = %d, 期望 2", r)
+	}
+	p.Put(nil)
+}
+
+func TestPoolConcurrent(t *testing.T) {
+	p := NewPool(4)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				p.Put(p.Get(16, 16))
+			}
+		}()
+	}
+	wg.Wait()
+	if s := p.Stats(); s.Gets != 800 || s.Puts != 800 || s.News > 8 {
+		t.Errorf("并发统计错误: %+v", s)
+	}
+}
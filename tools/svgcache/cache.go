@@ -0,0 +1,221 @@
+// cache.go
+package svgcache
+
+import (
+	"container/list"
+	"encoding/hex"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"sync"
+)
+
+// Key 光栅化结果的缓存键
+type Key struct {
+	Source string      // SVG 路径或内容摘要（见 HashSource）
+	Width  int         // 逻辑宽度
+	Height int         // 逻辑高度
+	Tint   color.NRGBA // 着色颜色，Tinted 为 false 时忽略
+	Tinted bool        // 是否着色，用于区分保持原色和着色为 color.Transparent
+	Scale  float32     // 缩放倍数（canvas.Scale）
+}
+
+// NewKey 创建缓存键，tint 为 nil 表示保持原色
+func NewKey(source string, width, height int, tint color.Color, scale float32) Key {
+	k := Key{Source: source, Width: width, Height: height, Scale: scale}
+	if tint != nil {
+		k.Tint = color.NRGBAModel.Convert(tint).(color.NRGBA)
+		k.Tinted = true
+	}
+	return k
+}
+
+// HashSource 计算 SVG 内容摘要，用于没有路径的内联 SVG
+func HashSource(data []byte) string {
+	h := fnv.New64a()
+	h.Write(data)
+	return "fnv:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// Stats 缓存命中统计
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+	Capacity  int
+	Bytes     int // 缓存图像的像素数据总字节数（估算）
+	MaxBytes  int // 字节上限，0 表示不限制
+}
+
+// HitRate 命中率（0~1），没有访问时为 0
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+type entry struct {
+	key  Key
+	img  image.Image
+	size int
+}
+
+// imageBytes 估算图像占用的字节数，未知类型按每像素 4 字节计算
+func imageBytes(img image.Image) int {
+	switch m := img.(type) {
+	case nil:
+		return 0
+	case *image.RGBA:
+		return len(m.Pix)
+	case *image.NRGBA:
+		return len(m.Pix)
+	case *image.Alpha:
+		return len(m.Pix)
+	case *image.Gray:
+		return len(m.Pix)
+	}
+	return img.Bounds().Dx() * img.Bounds().Dy() * 4
+}
+
+// Cache 带 LRU 淘汰的光栅化图像缓存，可在多个 goroutine 中使用
+// 缓存的图像会被多个控件共享，调用方不能修改取出的图像
+type Cache struct {
+	mu       sync.Mutex
+	capacity int
+	maxBytes int
+	bytes    int
+	order    *list.List // 队头为最近使用
+	items    map[Key]*list.Element
+	stats    Stats
+}
+
+// New 创建缓存，capacity 为最多保存的图像数量
+// 图标大小差别很大时可以再用 SetMaxBytes 限制总字节数
+func New(capacity int) *Cache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &Cache{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[Key]*list.Element),
+	}
+}
+
+// Shared 进程级共享缓存，供需要跨控件复用光栅化结果的调用方使用
+var Shared = New(256)
+
+// Get 读取缓存，命中时把该项移到最近使用的位置
+func (c *Cache) Get(k Key) (image.Image, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[k]; ok {
+		c.order.MoveToFront(el)
+		c.stats.Hits++
+		return el.Value.(*entry).img, true
+	}
+	c.stats.Misses++
+	return nil, false
+}
+
+// Put 写入缓存，超出容量时淘汰最久未使用的项
+func (c *Cache) Put(k Key, img image.Image) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	size := imageBytes(img)
+	if el, ok := c.items[k]; ok {
+		e := el.Value.(*entry)
+		c.bytes += size - e.size
+		e.img, e.size = img, size
+		c.order.MoveToFront(el)
+	} else {
+		c.items[k] = c.order.PushFront(&entry{key: k, img: img, size: size})
+		c.bytes += size
+	}
+	c.evict()
+}
+
+// GetOrRender 命中时直接返回，否则调用 render 光栅化并写入缓存
+// render 出错时不缓存
+func (c *Cache) GetOrRender(k Key, render func() (image.Image, error)) (image.Image, error) {
+	if img, ok := c.Get(k); ok {
+		return img, nil
+	}
+	img, err := render()
+	if err != nil {
+		return nil, err
+	}
+	c.Put(k, img)
+	return img, nil
+}
+
+// SetCapacity 调整容量，变小时立即淘汰多余的项
+func (c *Cache) SetCapacity(capacity int) {
+	if capacity < 1 {
+		capacity = 1
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.capacity = capacity
+	c.evict()
+}
+
+// SetMaxBytes 限制缓存图像的总字节数，n <= 0 表示不限制
+// 超出时淘汰最久未使用的项，但最近写入的一项总会保留
+func (c *Cache) SetMaxBytes(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.maxBytes = max(n, 0)
+	c.evict()
+}
+
+// Clear 清空缓存并重置统计
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	clear(c.items)
+	c.bytes = 0
+	c.stats = Stats{}
+}
+
+// Stats 返回当前统计信息，供 benchmark 使用
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := c.stats
+	s.Entries = c.order.Len()
+	s.Capacity = c.capacity
+	s.Bytes = c.bytes
+	s.MaxBytes = c.maxBytes
+	return s
+}
+
+// evict 淘汰最久未使用的项，直到数量和字节数都不超过上限
+func (c *Cache) evict() {
+	for c.order.Len() > c.capacity ||
+		(c.maxBytes > 0 && c.bytes > c.maxBytes && c.order.Len() > 1) {
+		c.removeOldest()
+	}
+}
+
+func (c *Cache) removeOldest() {
+	el := c.order.Back()
+	if el == nil {
+		return
+	}
+	c.order.Remove(el)
+	e := el.Value.(*entry)
+	delete(c.items, e.key)
+	c.bytes -= e.size
+	c.stats.Evictions++
+}
@@ -0,0 +1,200 @@
+package svgcache
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"sync"
+	"testing"
+)
+
+func img(w int) image.Image {
+	return image.NewRGBA(image.Rect(0, 0, w, w))
+}
+
+func TestCacheHitMiss(t *testing.T) {
+	c := New(4)
+	k := NewKey("svg/1.svg", 26, 26, color.RGBA{52, 152, 219, 255}, 1)
+
+	if _, ok := c.Get(k); ok {
+		t.Fatal("空缓存不应命中")
+	}
+	want := img(26)
+	c.Put(k, want)
+	if got, ok := c.Get(k); !ok || got != want {
+		t.Fatal("写入后应命中同一张图像")
+	}
+
+	s := c.Stats()
+	if s.Hits != 1 || s.Misses != 1 || s.Entries != 1 || s.Capacity != 4 {
+		t.Errorf("统计错误: %+v", s)
+	}
+	if s.HitRate() != 0.5 {
+		t.Errorf("HitRate = %v, 期望 0.5", s.HitRate())
+	}
+}
+
+func TestCacheKeyDistinguishesFields(t *testing.T) {
+	base := NewKey("svg/1.svg", 26, 26, color.Black, 1)
+	keys := []Key{
+		NewKey("svg/2.svg", 26, 26, color.Black, 1),
+		NewKey("svg/1.svg", 32, 26, color.Black, 1),
+		NewKey("svg/1.svg", 26, 26, color.White, 1),
+		NewKey("svg/1.svg", 26, 26, nil, 1),
+		NewKey("svg/1.svg", 26, 26, color.Black, 2),
+		NewKey("svg/1.svg", 26, 26, color.Transparent, 1),
+	}
+
+	c := New(10)
+	c.Put(base, img(1))
+	for i, k := range keys {
+		if _, ok := c.Get(k); ok {
+			t.Errorf("第 %d 个键不应命中 base", i)
+		}
+	}
+	// 等价的颜色表示应得到同一个键
+	if NewKey("a", 1, 1, color.RGBA{0, 0, 0, 255}, 1) != NewKey("a", 1, 1, color.Gray{0}, 1) {
+		t.Error("等价颜色应生成相同的键")
+	}
+	// 保持原色和着色为透明是两种不同的结果
+	if NewKey("a", 1, 1, nil, 1) == NewKey("a", 1, 1, color.Transparent, 1) {
+		t.Error("nil 和 color.Transparent 不应生成相同的键")
+	}
+}
+
+func TestCacheLRUEviction(t *testing.T) {
+	c := New(2)
+	a := NewKey("a", 1, 1, nil, 1)
+	b := NewKey("b", 1, 1, nil, 1)
+	d := NewKey("d", 1, 1, nil, 1)
+
+	c.Put(a, img(1))
+	c.Put(b, img(1))
+	c.Get(a) // a 变为最近使用，b 成为最久未使用
+	c.Put(d, img(1))
+
+	if _, ok := c.Get(b); ok {
+		t.Error("b 应被淘汰")
+	}
+	if _, ok := c.Get(a); !ok {
+		t.Error("a 应保留")
+	}
+	if _, ok := c.Get(d); !ok {
+		t.Error("d 应保留")
+	}
+	if s := c.Stats(); s.Evictions != 1 || s.Entries != 2 {
+		t.Errorf("统计错误: %+v", s)
+	}
+
+	c.SetCapacity(1)
+	if s := c.Stats(); s.Entries != 1 || s.Evictions != 2 {
+		t.Errorf("缩小容量后统计错误: %+v", s)
+	}
+	if _, ok := c.Get(d); !ok {
+		t.Error("缩小容量后应保留最近使用的 d")
+	}
+}
+
+func TestCacheMaxBytes(t *testing.T) {
+	c := New(10)
+	c.SetMaxBytes(3 * 16 * 16 * 4)
+
+	keys := []Key{
+		NewKey("a", 16, 16, nil, 1),
+		NewKey("b", 16, 16, nil, 1),
+		NewKey("c", 16, 16, nil, 1),
+	}
+	for _, k := range keys {
+		c.Put(k, img(16))
+	}
+	if s := c.Stats(); s.Entries != 3 || s.Bytes != 3*16*16*4 || s.Evictions != 0 {
+		t.Fatalf("未超出字节上限时不应淘汰: %+v", s)
+	}
+
+	// 一张 32x32 的图像相当于四张 16x16，需要淘汰最久未使用的项直到放得下
+	c.Get(keys[0])
+	big := NewKey("big", 32, 32, nil, 1)
+	c.Put(big, img(32))
+	if _, ok := c.Get(big); !ok {
+		t.Fatal("最近写入的项应保留")
+	}
+	s := c.Stats()
+	if s.Entries != 1 || s.Bytes != 32*32*4 || s.Evictions != 3 {
+		t.Errorf("按字节淘汰后统计错误: %+v", s)
+	}
+
+	// 替换同一个键时字节数按新图像重新计算
+	c.SetMaxBytes(0)
+	c.Put(big, img(8))
+	if s := c.Stats(); s.Bytes != 8*8*4 || s.MaxBytes != 0 {
+		t.Errorf("替换后字节数错误: %+v", s)
+	}
+}
+
+func TestCacheGetOrRender(t *testing.T) {
+	c := New(4)
+	k := NewKey(HashSource([]byte("<svg/>")), 16, 16, nil, 1.5)
+
+	calls := 0
+	render := func() (image.Image, error) {
+		calls++
+		return img(24), nil
+	}
+	first, err := c.GetOrRender(k, render)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := c.GetOrRender(k, render)
+	if calls != 1 || first != second {
+		t.Errorf("第二次应命中缓存，render 调用 %d 次", calls)
+	}
+
+	failKey := NewKey("bad", 1, 1, nil, 1)
+	if _, err := c.GetOrRender(failKey, func() (image.Image, error) {
+		return nil, errors.New("解析失败")
+	}); err == nil {
+		t.Error("render 出错时应返回错误")
+	}
+	if _, ok := c.Get(failKey); ok {
+		t.Error("出错的结果不应被缓存")
+	}
+}
+
+func TestCacheClear(t *testing.T) {
+	c := New(2)
+	k := NewKey("a", 1, 1, nil, 1)
+	c.Put(k, img(1))
+	c.Get(k)
+	c.Clear()
+	if s := c.Stats(); s != (Stats{Capacity: 2}) {
+		t.Errorf("Clear 后统计应归零: %+v", s)
+	}
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	c := New(8)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				k := NewKey("svg", j%16, i, nil, 1)
+				c.GetOrRender(k, func() (image.Image, error) { return img(1), nil })
+			}
+		}(i)
+	}
+	wg.Wait()
+	if s := c.Stats(); s.Hits+s.Misses != 800 || s.Entries > 8 {
+		t.Errorf("并发统计错误: %+v", s)
+	}
+}
+
+func TestHashSource(t *testing.T) {
+	if HashSource([]byte("a")) == HashSource([]byte("b")) {
+		t.Error("不同内容应生成不同摘要")
+	}
+	if HashSource([]byte("a")) != HashSource([]byte("a")) {
+		t.Error("相同内容应生成相同摘要")
+	}
+}
@@ -0,0 +1,185 @@
+// bidi.go
+package text
+
+import "golang.org/x/text/unicode/bidi"
+
+// resolveLevels 按 Unicode 双向算法（UAX #9）的隐式规则计算每个字符的嵌入层级
+//
+// 实现了 W1~W7、N1~N2、I1~I2 和 L1 中的行尾空白规则；
+// 显式嵌入/隔离控制符（LRE、RLI 等）和成对括号规则（N0）不支持，控制符按中性字符处理。
+// 这足以覆盖控件标签中常见的情况：RTL 文本中夹杂数字、拉丁文，或 LTR 文本中夹杂阿拉伯文。
+func resolveLevels(runes []rune, paraLevel uint8) []uint8 {
+	n := len(runes)
+	levels := make([]uint8, n)
+	if n == 0 {
+		return levels
+	}
+
+	sos := bidi.L
+	if paraLevel%2 == 1 {
+		sos = bidi.R
+	}
+
+	types := make([]bidi.Class, n)
+	orig := make([]bidi.Class, n)
+	for i, r := range runes {
+		p, _ := bidi.LookupRune(r)
+		orig[i] = p.Class()
+		types[i] = orig[i]
+		if isExplicitControl(types[i]) {
+			types[i] = bidi.ON
+		}
+	}
+
+	// W1：非间距标记取前一个字符的类型
+	for i, c := range types {
+		if c == bidi.NSM {
+			if i == 0 {
+				types[i] = sos
+			} else {
+				types[i] = types[i-1]
+			}
+		}
+	}
+
+	// W2：EN 前面最近的强字符是 AL 时变为 AN；W3：AL 变为 R
+	last := sos
+	for i, c := range types {
+		switch c {
+		case bidi.L, bidi.R, bidi.AL:
+			last = c
+		case bidi.EN:
+			if last == bidi.AL {
+				types[i] = bidi.AN
+			}
+		}
+	}
+	for i, c := range types {
+		if c == bidi.AL {
+			types[i] = bidi.R
+		}
+	}
+
+	// W4：两个 EN 之间的单个 ES 变为 EN；两个相同数字类型之间的单个 CS 变为该类型
+	for i := 1; i+1 < n; i++ {
+		prev, next := types[i-1], types[i+1]
+		switch types[i] {
+		case bidi.ES:
+			if prev == bidi.EN && next == bidi.EN {
+				types[i] = bidi.EN
+			}
+		case bidi.CS:
+			if prev == next && (prev == bidi.EN || prev == bidi.AN) {
+				types[i] = prev
+			}
+		}
+	}
+
+	// W5：与 EN 相邻的一串 ET 变为 EN
+	for i := 0; i < n; {
+		if types[i] != bidi.ET {
+			i++
+			continue
+		}
+		j := i
+		for j < n && types[j] == bidi.ET {
+			j++
+		}
+		if (i > 0 && types[i-1] == bidi.EN) || (j < n && types[j] == bidi.EN) {
+			for k := i; k < j; k++ {
+				types[k] = bidi.EN
+			}
+		}
+		i = j
+	}
+
+	// W6：剩下的分隔符和终止符变为中性字符
+	for i, c := range types {
+		if c == bidi.ES || c == bidi.ET || c == bidi.CS {
+			types[i] = bidi.ON
+		}
+	}
+
+	// W7：EN 前面最近的强字符是 L 时变为 L
+	last = sos
+	for i, c := range types {
+		switch c {
+		case bidi.L, bidi.R:
+			last = c
+		case bidi.EN:
+			if last == bidi.L {
+				types[i] = bidi.L
+			}
+		}
+	}
+
+	// N1/N2：一串中性字符两侧方向相同时取该方向，否则取段落方向（数字按 R 处理）
+	strong := func(c bidi.Class) (bidi.Class, bool) {
+		switch c {
+		case bidi.L:
+			return bidi.L, true
+		case bidi.R, bidi.EN, bidi.AN:
+			return bidi.R, true
+		}
+		return 0, false
+	}
+	for i := 0; i < n; {
+		if _, ok := strong(types[i]); ok {
+			i++
+			continue
+		}
+		j := i
+		for j < n {
+			if _, ok := strong(types[j]); ok {
+				break
+			}
+			j++
+		}
+		before, after := sos, sos
+		if i > 0 {
+			before, _ = strong(types[i-1])
+		}
+		if j < n {
+			after, _ = strong(types[j])
+		}
+		dir := sos
+		if before == after {
+			dir = before
+		}
+		for k := i; k < j; k++ {
+			types[k] = dir
+		}
+		i = j
+	}
+
+	// I1/I2：由类型和段落层级得到最终层级
+	for i, c := range types {
+		lvl := paraLevel
+		if lvl%2 == 0 {
+			switch c {
+			case bidi.R:
+				lvl++
+			case bidi.AN, bidi.EN:
+				lvl += 2
+			}
+		} else if c == bidi.L || c == bidi.EN || c == bidi.AN {
+			lvl++
+		}
+		levels[i] = lvl
+	}
+
+	// L1：行尾的空白恢复为段落层级
+	for i := n - 1; i >= 0; i-- {
+		c := orig[i]
+		if c != bidi.WS && c != bidi.S && c != bidi.B && c != bidi.BN && !isExplicitControl(c) {
+			break
+		}
+		levels[i] = paraLevel
+	}
+	return levels
+}
+
+// isExplicitControl 显式嵌入、覆盖和隔离控制符
+func isExplicitControl(c bidi.Class) bool {
+	return c >= bidi.Control
+}
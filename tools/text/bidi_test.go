@@ -0,0 +1,34 @@
+package text
+
+import "testing"
+
+func TestResolveLevels(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		para uint8
+		want []uint8
+	}{
+		{"纯拉丁文", "ab c", 0, []uint8{0, 0, 0, 0}},
+		{"LTR 中的阿拉伯文", "a سل b", 0, []uint8{0, 0, 1, 1, 0, 0}},
+		{"阿拉伯文后的数字为 AN", "سل 12", 0, []uint8{1, 1, 1, 2, 2}},
+		{"拉丁文后的数字为 L", "ab 12", 0, []uint8{0, 0, 0, 0, 0}},
+		{"RTL 中的拉丁文", "سل ab", 1, []uint8{1, 1, 1, 2, 2}},
+		{"数字之间的分隔符", "سل 1.5", 0, []uint8{1, 1, 1, 2, 2, 2}},
+		{"非间距标记跟随前一个字符", "سَ", 0, []uint8{1, 1}},
+		{"行尾空白恢复段落层级", "سل  ", 0, []uint8{1, 1, 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveLevels([]rune(tt.text), tt.para)
+			if len(got) != len(tt.want) {
+				t.Fatalf("levels = %v, 期望 %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("levels = %v, 期望 %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// fallback.go
+package text
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"os"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// FaceChain 字体回退链：按顺序为每个字符选择第一个包含该字形的字体
+//
+// FaceChain 本身实现了 font.Face，可以直接交给 gg.Context.SetFontFace 使用，
+// 这样中英文混排时不需要再为每个控件单独挑选 chinese.ttf 或 english.ttf
+type FaceChain struct {
+	faces []font.Face
+}
+
+// 确保 FaceChain 满足 font.Face 接口
+var _ font.Face = (*FaceChain)(nil)
+
+// NewFaceChain 创建字体回退链，faces 越靠前优先级越高
+func NewFaceChain(faces ...font.Face) (*FaceChain, error) {
+	if len(faces) == 0 {
+		return nil, errors.New("字体回退链至少需要一个字体")
+	}
+	for i, f := range faces {
+		if f == nil {
+			return nil, fmt.Errorf("第 %d 个字体为空", i)
+		}
+	}
+	return &FaceChain{faces: append([]font.Face(nil), faces...)}, nil
+}
+
+// LoadFaceChain 从字体文件依次加载字体并组成回退链
+// points 为字号，DPI 与 gg.LoadFontFace 保持一致（72）
+func LoadFaceChain(points float64, paths ...string) (*FaceChain, error) {
+	faces := make([]font.Face, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			closeFaces(faces)
+			return nil, fmt.Errorf("读取字体文件失败 %s: %w", path, err)
+		}
+		f, err := opentype.Parse(data)
+		if err != nil {
+			closeFaces(faces)
+			return nil, fmt.Errorf("解析字体文件失败 %s: %w", path, err)
+		}
+		face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: points, DPI: 72})
+		if err != nil {
+			closeFaces(faces)
+			return nil, fmt.Errorf("创建字体失败 %s: %w", path, err)
+		}
+		faces = append(faces, face)
+	}
+	return NewFaceChain(faces...)
+}
+
+// Faces 返回回退链中的字体（按优先级排列）
+func (c *FaceChain) Faces() []font.Face {
+	return append([]font.Face(nil), c.faces...)
+}
+
+// FaceFor 返回第一个包含字符 r 的字体
+// 如果所有字体都不包含该字符，返回链中的第一个字体（由它绘制缺字符号）
+func (c *FaceChain) FaceFor(r rune) font.Face {
+	face, _ := c.lookup(r)
+	return face
+}
+
+// Has 判断回退链中是否有字体包含字符 r
+func (c *FaceChain) Has(r rune) bool {
+	_, ok := c.lookup(r)
+	return ok
+}
+
+func (c *FaceChain) lookup(r rune) (font.Face, bool) {
+	for _, f := range c.faces {
+		if _, ok := f.GlyphAdvance(r); ok {
+			return f, true
+		}
+	}
+	return c.faces[0], false
+}
+
+// Close 关闭链中的所有字体
+func (c *FaceChain) Close() error {
+	var errs []error
+	for _, f := range c.faces {
+		if err := f.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Glyph 使用包含该字符的字体绘制字形
+func (c *FaceChain) Glyph(dot fixed.Point26_6, r rune) (
+	dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	return c.FaceFor(r).Glyph(dot, r)
+}
+
+// GlyphBounds 返回字符 r 在其所选字体中的边界
+func (c *FaceChain) GlyphBounds(r rune) (bounds fixed.Rectangle26_6, advance fixed.Int26_6, ok bool) {
+	return c.FaceFor(r).GlyphBounds(r)
+}
+
+// GlyphAdvance 返回字符 r 在其所选字体中的步进宽度
+func (c *FaceChain) GlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	return c.FaceFor(r).GlyphAdvance(r)
+}
+
+// Kern 只有两个字符落在同一个字体时才应用字距调整
+func (c *FaceChain) Kern(r0, r1 rune) fixed.Int26_6 {
+	f0, f1 := c.FaceFor(r0), c.FaceFor(r1)
+	if f0 != f1 {
+		return 0
+	}
+	return f0.Kern(r0, r1)
+}
+
+// Metrics 取所有字体中最大的上升/下降高度，保证混排时行高足够
+func (c *FaceChain) Metrics() font.Metrics {
+	m := c.faces[0].Metrics()
+	for _, f := range c.faces[1:] {
+		fm := f.Metrics()
+		m.Height = max(m.Height, fm.Height)
+		m.Ascent = max(m.Ascent, fm.Ascent)
+		m.Descent = max(m.Descent, fm.Descent)
+	}
+	return m
+}
+
+func closeFaces(faces []font.Face) {
+	for _, f := range faces {
+		f.Close()
+	}
+}
@@ -0,0 +1,110 @@
+package text
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+func newGoRegular(t *testing.T, size float64) font.Face {
+	t.Helper()
+	f, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("解析字体失败: %v", err)
+	}
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72})
+	if err != nil {
+		t.Fatalf("创建字体失败: %v", err)
+	}
+	return face
+}
+
+func TestFaceChainFaceFor(t *testing.T) {
+	basic := basicfont.Face7x13
+	goreg := newGoRegular(t, 20)
+	chain, err := NewFaceChain(basic, goreg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		r    rune
+		want font.Face
+		has  bool
+	}{
+		{"拉丁字母取第一个字体", 'A', basic, true},
+		{"希腊字母回退到第二个字体", 'Ω', goreg, true},
+		{"都没有时使用第一个字体", '中', basic, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chain.FaceFor(tt.r); got != tt.want {
+				t.Errorf("FaceFor(%q) 选择了错误的字体", tt.r)
+			}
+			if got := chain.Has(tt.r); got != tt.has {
+				t.Errorf("Has(%q) = %v, 期望 %v", tt.r, got, tt.has)
+			}
+		})
+	}
+}
+
+func TestFaceChainDrawsMixedText(t *testing.T) {
+	basic := basicfont.Face7x13
+	goreg := newGoRegular(t, 20)
+	chain, err := NewFaceChain(basic, goreg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 'A' 来自 basicfont（固定 7px 步进），'Ω' 来自 goregular
+	wantA, _ := basic.GlyphAdvance('A')
+	wantOmega, _ := goreg.GlyphAdvance('Ω')
+	if got := font.MeasureString(chain, "AΩ"); got != wantA+wantOmega {
+		t.Errorf("MeasureString = %v, 期望 %v", got, wantA+wantOmega)
+	}
+
+	if k := chain.Kern('A', 'Ω'); k != 0 {
+		t.Errorf("跨字体字距应为 0，实际 %v", k)
+	}
+
+	m := chain.Metrics()
+	if m.Ascent < goreg.Metrics().Ascent || m.Ascent < basic.Metrics().Ascent {
+		t.Errorf("Metrics 应取最大上升高度，实际 %v", m.Ascent)
+	}
+}
+
+func TestNewFaceChainRejectsEmpty(t *testing.T) {
+	if _, err := NewFaceChain(); err == nil {
+		t.Error("空回退链应返回错误")
+	}
+	if _, err := NewFaceChain(basicfont.Face7x13, nil); err == nil {
+		t.Error("包含空字体时应返回错误")
+	}
+}
+
+func TestLoadFaceChain(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "goregular.ttf")
+	if err := os.WriteFile(path, goregular.TTF, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	chain, err := LoadFaceChain(16, path)
+	if err != nil {
+		t.Fatalf("LoadFaceChain 失败: %v", err)
+	}
+	defer chain.Close()
+	if len(chain.Faces()) != 1 || !chain.Has('a') {
+		t.Error("加载后的回退链应包含 goregular")
+	}
+
+	if _, err := LoadFaceChain(16, path, filepath.Join(dir, "missing.ttf")); err == nil {
+		t.Error("字体文件不存在时应返回错误")
+	}
+}
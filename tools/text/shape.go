@@ -0,0 +1,203 @@
+// shape.go
+package text
+
+import (
+	"image/color"
+	"image/draw"
+
+	"github.com/go-text/render"
+	"github.com/go-text/typesetting/di"
+	"github.com/go-text/typesetting/font"
+	"github.com/go-text/typesetting/language"
+	"github.com/go-text/typesetting/shaping"
+	"golang.org/x/image/math/fixed"
+)
+
+// FontMap 按顺序排列的塑形字体列表，逐字符选取第一个包含该字形的字体
+// 它是 FaceChain 在塑形路径上的对应物，例如 chinese.ttf 之后接阿拉伯文字体
+type FontMap struct {
+	faces []*font.Face
+}
+
+// 确保 FontMap 满足 shaping.Fontmap 接口
+var _ shaping.Fontmap = (*FontMap)(nil)
+
+// NewFontMap 创建塑形字体列表，faces 越靠前优先级越高，nil 会被忽略
+func NewFontMap(faces ...*font.Face) *FontMap {
+	fm := &FontMap{}
+	for _, f := range faces {
+		if f != nil {
+			fm.faces = append(fm.faces, f)
+		}
+	}
+	return fm
+}
+
+// ResolveFace 返回第一个包含字符 r 的字体，都不包含时返回第一个字体
+func (fm *FontMap) ResolveFace(r rune) *font.Face {
+	for _, f := range fm.faces {
+		if _, ok := f.NominalGlyph(r); ok {
+			return f
+		}
+	}
+	if len(fm.faces) == 0 {
+		return nil
+	}
+	return fm.faces[0]
+}
+
+// Glyph 经过塑形并定位好的字形
+type Glyph struct {
+	ID   font.GID   // 字体中的字形编号
+	Face *font.Face // 绘制该字形使用的字体
+	// X, Y 为字形绘制原点相对文本起点（基线左端）的偏移
+	// Y 向下为正，与 gg / image 坐标一致
+	X, Y     fixed.Int26_6
+	XAdvance fixed.Int26_6
+	// Cluster 为该字形对应的第一个字符在输入文本（按 rune）中的下标
+	// RuneCount 为该字形簇覆盖的字符数，连字和组合字符时大于 1
+	Cluster   int
+	RuneCount int
+}
+
+// ShapedText 一行文本的塑形结果，Glyphs 和 Runs 都已按视觉顺序（从左到右）排列
+type ShapedText struct {
+	Glyphs  []Glyph
+	Runs    []shaping.Output // 塑形后的各段，Draw 按段光栅化
+	Size    float64          // 塑形时的像素大小
+	Advance fixed.Int26_6    // 整行宽度
+	Ascent  fixed.Int26_6
+	Descent fixed.Int26_6 // 正值，基线以下的高度
+}
+
+// Shaper 文本塑形器：先按双向算法层级切分，再按文字和字体切分，最后用 HarfBuzz 塑形每一段
+// 可以正确处理阿拉伯文连写、希伯来文、天城文、组合字符和连字
+// Shaper 内部有缓存，不能在多个 goroutine 中同时使用
+type Shaper struct {
+	// Direction 段落的基础方向，零值为从左到右
+	Direction di.Direction
+	// Language 文本语言，为空时只依据文字（script）塑形
+	Language language.Language
+
+	hb  shaping.HarfbuzzShaper
+	seg shaping.Segmenter
+}
+
+// levelRun 同一嵌入层级的一段已塑形文本
+type levelRun struct {
+	level uint8
+	out   shaping.Output
+}
+
+// Shape 用单个字体以 size 像素大小塑形单行文本
+func (s *Shaper) Shape(str string, face *font.Face, size float64) ShapedText {
+	return s.ShapeWithFonts(str, NewFontMap(face), size)
+}
+
+// ShapeWithFonts 用字体列表塑形单行文本，每个字符使用 fonts 中第一个包含它的字体
+func (s *Shaper) ShapeWithFonts(str string, fonts *FontMap, size float64) ShapedText {
+	runes := []rune(str)
+	out := ShapedText{Size: size}
+	if len(runes) == 0 || fonts == nil || len(fonts.faces) == 0 {
+		return out
+	}
+
+	paraLevel := uint8(0)
+	if s.Direction.Progression() == di.TowardTopLeft {
+		paraLevel = 1
+	}
+	levels := resolveLevels(runes, paraLevel)
+
+	// 每个层级段内部方向一致，再交给 Segmenter 按文字和字体细分
+	var runs []levelRun
+	for start := 0; start < len(runes); {
+		end := start
+		for end < len(runes) && levels[end] == levels[start] {
+			end++
+		}
+		dir := s.Direction
+		if levels[start]%2 == 1 {
+			dir.SetProgression(di.TowardTopLeft)
+		} else {
+			dir.SetProgression(di.FromTopLeft)
+		}
+		input := shaping.Input{
+			Text:      runes,
+			RunStart:  start,
+			RunEnd:    end,
+			Direction: dir,
+			Size:      fixed.Int26_6(size * 64),
+			Language:  s.Language,
+		}
+		// Split 返回的切片归 Segmenter 所有，塑形结果需要拷贝出来
+		for _, part := range s.seg.Split(input, fonts) {
+			runs = append(runs, levelRun{level: levels[start], out: s.hb.Shape(part)})
+		}
+		start = end
+	}
+
+	for _, r := range visualOrder(runs) {
+		o := r.out
+		out.Runs = append(out.Runs, o)
+		out.Ascent = max(out.Ascent, o.LineBounds.Ascent)
+		out.Descent = max(out.Descent, -o.LineBounds.Descent)
+		for _, g := range o.Glyphs {
+			out.Glyphs = append(out.Glyphs, Glyph{
+				ID:        g.GlyphID,
+				Face:      o.Face,
+				X:         out.Advance + g.XOffset,
+				Y:         -g.YOffset,
+				XAdvance:  g.XAdvance,
+				Cluster:   g.ClusterIndex,
+				RuneCount: g.RuneCount,
+			})
+			out.Advance += g.XAdvance
+		}
+	}
+	return out
+}
+
+// visualOrder 按 Unicode 双向算法 L2 规则重排：
+// 从最高层级到最低的奇数层级，依次翻转层级不低于当前值的每一串连续段
+func visualOrder(runs []levelRun) []levelRun {
+	ordered := append([]levelRun(nil), runs...)
+	if len(ordered) == 0 {
+		return ordered
+	}
+	highest, lowest := ordered[0].level, ordered[0].level
+	for _, r := range ordered {
+		highest = max(highest, r.level)
+		lowest = min(lowest, r.level)
+	}
+
+	for lvl := highest; lvl >= lowest|1; lvl-- {
+		for i := 0; i < len(ordered); {
+			if ordered[i].level < lvl {
+				i++
+				continue
+			}
+			j := i
+			for j < len(ordered) && ordered[j].level >= lvl {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				ordered[a], ordered[b] = ordered[b], ordered[a]
+			}
+			i = j
+		}
+	}
+	return ordered
+}
+
+// Draw 把塑形结果光栅化到 dst，(x, y) 为基线左端的像素坐标
+// 返回绘制结束时的 x 坐标
+func Draw(dst draw.Image, st ShapedText, x, y int, col color.Color) int {
+	r := render.Renderer{FontSize: float32(st.Size), PixScale: 1, Color: col}
+	pen := fixed.I(x)
+	for _, run := range st.Runs {
+		// Renderer 按整数像素返回结束位置，这里用塑形的精确步进累加，避免逐段取整带来的误差
+		r.DrawShapedRunAt(run, dst, pen.Round(), y)
+		pen += run.Advance
+	}
+	return pen.Round()
+}
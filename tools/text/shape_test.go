@@ -0,0 +1,195 @@
+package text
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2/theme"
+	"github.com/go-text/typesetting/di"
+	"github.com/go-text/typesetting/font"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func loadShapingFace(t *testing.T) *font.Face {
+	t.Helper()
+	face, err := font.ParseTTF(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatalf("解析字体失败: %v", err)
+	}
+	return face
+}
+
+func clusters(st ShapedText) []int {
+	var c []int
+	for _, g := range st.Glyphs {
+		c = append(c, g.Cluster)
+	}
+	return c
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestShapeLatin(t *testing.T) {
+	var s Shaper
+	st := s.Shape("Hello", loadShapingFace(t), 20)
+
+	if want := []int{0, 1, 2, 3, 4}; !equalInts(clusters(st), want) {
+		t.Fatalf("clusters = %v, 期望 %v", clusters(st), want)
+	}
+	var x int
+	for i, g := range st.Glyphs {
+		if g.ID == 0 {
+			t.Errorf("第 %d 个字形缺失", i)
+		}
+		if int(g.X) < x {
+			t.Errorf("第 %d 个字形位置回退: %v", i, g.X)
+		}
+		x = int(g.X)
+	}
+	if st.Advance <= 0 || st.Ascent <= 0 || st.Descent <= 0 {
+		t.Errorf("度量异常: advance=%v ascent=%v descent=%v", st.Advance, st.Ascent, st.Descent)
+	}
+}
+
+func TestShapeArabicIsRightToLeft(t *testing.T) {
+	var s Shaper
+	// "سلام"：4 个字符，阿拉伯文从右向左，视觉上最左边是最后一个字符
+	st := s.Shape("سلام", loadShapingFace(t), 20)
+
+	if want := []int{3, 2, 1, 0}; !equalInts(clusters(st), want) {
+		t.Errorf("clusters = %v, 期望 %v", clusters(st), want)
+	}
+}
+
+func TestShapeMixedBidi(t *testing.T) {
+	face := loadShapingFace(t)
+	text := "ab سلام cd"
+
+	var ltr Shaper
+	got := clusters(ltr.Shape(text, face, 20))
+	// 从左到右段落：拉丁文保持顺序，中间的阿拉伯文整体翻转
+	want := []int{0, 1, 2, 6, 5, 4, 3, 7, 8, 9}
+	if !equalInts(got, want) {
+		t.Errorf("LTR clusters = %v, 期望 %v", got, want)
+	}
+
+	rtl := Shaper{Direction: di.DirectionRTL}
+	got = clusters(rtl.Shape(text, face, 20))
+	// 从右到左段落：段的顺序翻转，拉丁文段内部仍从左到右
+	want = []int{8, 9, 7, 6, 5, 4, 3, 2, 0, 1}
+	if !equalInts(got, want) {
+		t.Errorf("RTL clusters = %v, 期望 %v", got, want)
+	}
+}
+
+func TestShapeNumbersInsideRTL(t *testing.T) {
+	face := loadShapingFace(t)
+	// 阿拉伯文中夹杂数字：数字保持从左到右，两个阿拉伯单词按从右到左排列
+	text := "ab سلام 123 عليكم cd"
+
+	var ltr Shaper
+	got := clusters(ltr.Shape(text, face, 20))
+	want := []int{0, 1, 2, 16, 15, 14, 13, 12, 11, 8, 9, 10, 7, 6, 5, 4, 3, 17, 18, 19}
+	if !equalInts(got, want) {
+		t.Errorf("LTR clusters = %v, 期望 %v", got, want)
+	}
+
+	rtl := Shaper{Direction: di.DirectionRTL}
+	got = clusters(rtl.Shape("سلام 123", face, 20))
+	want = []int{5, 6, 7, 4, 3, 2, 1, 0}
+	if !equalInts(got, want) {
+		t.Errorf("RTL clusters = %v, 期望 %v", got, want)
+	}
+}
+
+func TestShapeWithFontsFallback(t *testing.T) {
+	regular := loadShapingFace(t)
+	symbols, err := font.ParseTTF(bytes.NewReader(theme.DefaultSymbolFont().Content()))
+	if err != nil {
+		t.Fatalf("解析符号字体失败: %v", err)
+	}
+	fonts := NewFontMap(regular, symbols)
+
+	tests := []struct {
+		r    rune
+		want *font.Face
+	}{
+		{'a', regular},
+		{'⌘', symbols}, // goregular 中没有，回退到符号字体
+		{'中', regular}, // 都没有时使用第一个字体
+	}
+	for _, tt := range tests {
+		if got := fonts.ResolveFace(tt.r); got != tt.want {
+			t.Errorf("ResolveFace(%q) 选择了错误的字体", tt.r)
+		}
+	}
+
+	var s Shaper
+	st := s.ShapeWithFonts("a⌘b", fonts, 20)
+	if len(st.Glyphs) != 3 {
+		t.Fatalf("字形数量 = %d, 期望 3", len(st.Glyphs))
+	}
+	for i, want := range []*font.Face{regular, symbols, regular} {
+		if g := st.Glyphs[i]; g.Face != want || g.ID == 0 {
+			t.Errorf("第 %d 个字形的字体或编号错误: %+v", i, g)
+		}
+	}
+}
+
+func TestDraw(t *testing.T) {
+	var s Shaper
+	st := s.Shape("Hi", loadShapingFace(t), 20)
+
+	img := image.NewRGBA(image.Rect(0, 0, 60, 30))
+	end := Draw(img, st, 5, 22, color.Black)
+	if end != 5+st.Advance.Round() {
+		t.Errorf("Draw 返回 %d, 期望 %d", end, 5+st.Advance.Round())
+	}
+
+	inked := 0
+	for y := 0; y < 30; y++ {
+		for x := 0; x < 60; x++ {
+			if img.RGBAAt(x, y).A == 0 {
+				continue
+			}
+			inked++
+			if x < 5 || x > end+1 || y > 22+st.Descent.Ceil() {
+				t.Fatalf("像素 (%d,%d) 超出文本范围", x, y)
+			}
+		}
+	}
+	if inked == 0 {
+		t.Error("没有绘制任何像素")
+	}
+}
+
+func TestShapeCombiningMark(t *testing.T) {
+	var s Shaper
+	// e + 组合重音符应合成一个字形簇
+	st := s.Shape("e\u0301", loadShapingFace(t), 20)
+	if len(st.Glyphs) == 0 {
+		t.Fatal("没有输出字形")
+	}
+	if g := st.Glyphs[0]; g.Cluster != 0 || g.RuneCount != 2 {
+		t.Errorf("组合字符簇 = (%d, %d), 期望 (0, 2)", g.Cluster, g.RuneCount)
+	}
+}
+
+func TestShapeEmpty(t *testing.T) {
+	var s Shaper
+	if st := s.Shape("", loadShapingFace(t), 20); len(st.Glyphs) != 0 || st.Advance != 0 {
+		t.Error("空字符串应返回空结果")
+	}
+}
@@ -0,0 +1,103 @@
+// canvas.go
+package vector
+
+import (
+	"image"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+)
+
+// Backend 控件的渲染方式，每个控件可以单独选择
+type Backend int
+
+const (
+	// BackendRaster 用 gg 光栅化为一张图像，与现有控件的做法一致
+	BackendRaster Backend = iota
+	// BackendCanvas 转换为 Fyne 原生 canvas 对象，由 Fyne 的渲染器绘制，
+	// 尺寸很大时省去整张图像的内存和 CPU 开销；路径仍按自身外接矩形光栅化
+	BackendCanvas
+)
+
+// String 返回渲染方式名称，供 benchmark 页面显示
+func (b Backend) String() string {
+	if b == BackendCanvas {
+		return "canvas"
+	}
+	return "gg"
+}
+
+// Objects 按 b 把 d 转换为 Fyne canvas 对象，位置相对于控件左上角
+// r 为 nil 时使用新的 Rasterizer；返回的对象共用 r，只能在 Fyne 的渲染线程中刷新
+func (d *Drawing) Objects(b Backend, r *Rasterizer) []fyne.CanvasObject {
+	if r == nil {
+		r = &Rasterizer{}
+	}
+	if b == BackendRaster {
+		img := rasterObject(d, r)
+		img.Resize(d.Size)
+		return []fyne.CanvasObject{img}
+	}
+
+	objs := make([]fyne.CanvasObject, 0, len(d.Items))
+	for _, item := range d.Items {
+		switch p := item.(type) {
+		case *RoundRect:
+			rect := &canvas.Rectangle{
+				FillColor:    p.Fill,
+				StrokeColor:  p.Stroke,
+				StrokeWidth:  p.StrokeWidth,
+				CornerRadius: p.Radius,
+			}
+			rect.Move(p.Pos)
+			rect.Resize(p.Size)
+			objs = append(objs, rect)
+		case *Circle:
+			c := &canvas.Circle{FillColor: p.Fill, StrokeColor: p.Stroke, StrokeWidth: p.StrokeWidth}
+			c.Move(p.Center.SubtractXY(p.Radius, p.Radius))
+			c.Resize(fyne.NewSquareSize(p.Radius * 2))
+			objs = append(objs, c)
+		case *Text:
+			t := canvas.NewText(p.Text, p.Color)
+			t.TextSize = textSize(p)
+			t.TextStyle = p.Style
+			t.Move(p.Pos)
+			t.Resize(t.MinSize())
+			objs = append(objs, t)
+		case *Path:
+			// Fyne 没有任意路径图元，只把路径所在的区域光栅化
+			pad := p.StrokeWidth / 2
+			lo, size := p.Bounds()
+			origin := lo.SubtractXY(pad, pad)
+			sub := NewDrawing(size.AddWidthHeight(pad*2, pad*2)).Add(p.translated(origin))
+			img := rasterObject(sub, r)
+			img.Move(origin)
+			img.Resize(sub.Size)
+			objs = append(objs, img)
+		}
+	}
+	return objs
+}
+
+// rasterObject 创建按实际像素尺寸光栅化 d 的 canvas.Raster
+func rasterObject(d *Drawing, r *Rasterizer) *canvas.Raster {
+	return canvas.NewRaster(func(w, h int) image.Image {
+		img := image.NewRGBA(image.Rect(0, 0, w, h))
+		if d.Size.Width > 0 {
+			r.Draw(img, d, float32(w)/d.Size.Width)
+		}
+		return img
+	})
+}
+
+// translated 返回平移 -origin 后的路径副本
+func (p *Path) translated(origin fyne.Position) *Path {
+	out := &Path{Paint: p.Paint, Segments: make([]PathSegment, len(p.Segments))}
+	for i, s := range p.Segments {
+		for j := range s.Pts {
+			s.Pts[j] = s.Pts[j].Subtract(origin)
+		}
+		out.Segments[i] = s
+	}
+	return out
+}
@@ -0,0 +1,82 @@
+package vector
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+
+	"2025-12-18-ggAndPng/tools/golden"
+)
+
+func TestObjectsCanvas(t *testing.T) {
+	objs := toggleDrawing().Objects(BackendCanvas, nil)
+	if len(objs) != 3 {
+		t.Fatalf("对象数 = %d, 期望 3", len(objs))
+	}
+
+	rect, ok := objs[0].(*canvas.Rectangle)
+	if !ok || rect.CornerRadius != 15 || rect.FillColor != red || rect.Size() != fyne.NewSize(60, 30) {
+		t.Errorf("圆角矩形转换错误: %#v", objs[0])
+	}
+	circle, ok := objs[1].(*canvas.Circle)
+	if !ok || circle.Position() != fyne.NewPos(33, 3) || circle.Size() != fyne.NewSquareSize(24) {
+		t.Errorf("圆形转换错误: %#v", objs[1])
+	}
+	// 路径按外接矩形加上一半描边宽度光栅化
+	path, ok := objs[2].(*canvas.Raster)
+	if !ok || path.Position() != fyne.NewPos(7, 10) || path.Size() != fyne.NewSize(14, 10) {
+		t.Errorf("路径转换错误: pos=%v size=%v", objs[2].Position(), objs[2].Size())
+	}
+
+	text := NewDrawing(fyne.NewSize(40, 20)).Add(&Text{Pos: fyne.NewPos(3, 4), Text: "关", Size: 12}).
+		Objects(BackendCanvas, nil)
+	if tx, ok := text[0].(*canvas.Text); !ok || tx.TextSize != 12 || tx.Position() != fyne.NewPos(3, 4) {
+		t.Errorf("文字转换错误: %#v", text[0])
+	}
+}
+
+func TestBackendsRenderAlike(t *testing.T) {
+	d := toggleDrawing()
+	snap := func(b Backend) fyne.CanvasObject {
+		return container.NewWithoutLayout(d.Objects(b, nil)...)
+	}
+
+	for _, scale := range []float32{1, 2} {
+		ggImg := golden.Snapshot(snap(BackendRaster), d.Size, scale)
+		nativeImg := golden.Snapshot(snap(BackendCanvas), d.Size, scale)
+		// 两种渲染器的抗锯齿不同，只要求边缘以外的像素一致
+		res := golden.Compare(ggImg, nativeImg, 0.1)
+		if res.SizeMismatch || res.DiffRatio() > 0.05 {
+			t.Errorf("缩放 %v: 两种渲染方式差异过大 %d/%d", scale, res.DiffPixels, res.TotalPixels)
+		}
+	}
+}
+
+func TestBackendString(t *testing.T) {
+	if BackendRaster.String() != "gg" || BackendCanvas.String() != "canvas" {
+		t.Error("Backend 名称错误")
+	}
+}
+
+// 对比两种渲染方式在大尺寸控件上的开销，go test -bench=Backend -benchmem
+// BackendCanvas 只计算转换开销，实际绘制由 Fyne 的渲染器完成
+func BenchmarkBackendRaster(b *testing.B) {
+	var r Rasterizer
+	d := toggleDrawing()
+	d.Size = fyne.NewSize(600, 300)
+	b.ReportAllocs()
+	for b.Loop() {
+		r.Rasterize(d, 2)
+	}
+}
+
+func BenchmarkBackendCanvas(b *testing.B) {
+	d := toggleDrawing()
+	d.Size = fyne.NewSize(600, 300)
+	b.ReportAllocs()
+	for b.Loop() {
+		d.Objects(BackendCanvas, nil)
+	}
+}
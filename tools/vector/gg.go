@@ -0,0 +1,161 @@
+// gg.go
+package vector
+
+import (
+	"image"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"github.com/fogleman/gg"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+
+	"2025-12-18-ggAndPng/tools/raster"
+)
+
+// faceKey 字体缓存键，size 为像素大小
+type faceKey struct {
+	size  float64
+	style fyne.TextStyle
+}
+
+// Rasterizer 用 gg 把 Drawing 光栅化为图像
+// 内部缓存字体，不能在多个 goroutine 中同时使用
+type Rasterizer struct {
+	// Face 返回像素大小为 size 的字体，nil 时使用 Fyne 默认主题的字体
+	// 需要显示中文时可以返回 text.FaceChain
+	Face func(size float64, style fyne.TextStyle) (font.Face, error)
+	// Limits 后备图像尺寸上限，零值表示使用 raster.DefaultLimits
+	Limits raster.Limits
+
+	faces map[faceKey]font.Face
+	fonts map[fyne.TextStyle]*opentype.Font
+}
+
+// Rasterize 以缩放 scale 把 d 光栅化为新图像，超出 Limits 时等比缩小
+func (r *Rasterizer) Rasterize(d *Drawing, scale float32) *image.RGBA {
+	lim := r.Limits
+	if lim == (raster.Limits{}) {
+		lim = raster.DefaultLimits
+	}
+	w, h, s := raster.BackingSize(d.Size, scale, lim)
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	r.Draw(img, d, s)
+	return img
+}
+
+// Draw 以缩放 scale 把 d 画到 dst 上，dst 原有内容不清除，可以配合缓冲池复用图像
+func (r *Rasterizer) Draw(dst *image.RGBA, d *Drawing, scale float32) {
+	dc := gg.NewContextForRGBA(dst)
+	s := float64(scale)
+	px := func(v float32) float64 { return float64(v) * s }
+
+	for _, item := range d.Items {
+		switch p := item.(type) {
+		case *RoundRect:
+			radius := min(p.Radius, p.Size.Width/2, p.Size.Height/2)
+			if radius > 0 {
+				dc.DrawRoundedRectangle(px(p.Pos.X), px(p.Pos.Y), px(p.Size.Width), px(p.Size.Height), px(radius))
+			} else {
+				dc.DrawRectangle(px(p.Pos.X), px(p.Pos.Y), px(p.Size.Width), px(p.Size.Height))
+			}
+			paint(dc, p.Paint, s)
+		case *Circle:
+			dc.DrawCircle(px(p.Center.X), px(p.Center.Y), px(p.Radius))
+			paint(dc, p.Paint, s)
+		case *Path:
+			for _, seg := range p.Segments {
+				a, b, c := seg.Pts[0], seg.Pts[1], seg.Pts[2]
+				switch seg.Op {
+				case OpMoveTo:
+					dc.MoveTo(px(a.X), px(a.Y))
+				case OpLineTo:
+					dc.LineTo(px(a.X), px(a.Y))
+				case OpQuadTo:
+					dc.QuadraticTo(px(a.X), px(a.Y), px(b.X), px(b.Y))
+				case OpCubicTo:
+					dc.CubicTo(px(a.X), px(a.Y), px(b.X), px(b.Y), px(c.X), px(c.Y))
+				case OpClose:
+					dc.ClosePath()
+				}
+			}
+			paint(dc, p.Paint, s)
+		case *Text:
+			if p.Color == nil || p.Text == "" {
+				continue
+			}
+			face, err := r.face(px(textSize(p)), p.Style)
+			if err != nil {
+				fyne.LogError("加载字体失败", err)
+				continue
+			}
+			dc.SetFontFace(face)
+			dc.SetColor(p.Color)
+			// gg 以基线定位文字，Text.Pos 为文字框左上角
+			ascent := float64(face.Metrics().Ascent) / 64
+			dc.DrawString(p.Text, px(p.Pos.X), px(p.Pos.Y)+ascent)
+		}
+	}
+}
+
+// paint 按 Paint 填充和描边当前路径，然后清除路径
+func paint(dc *gg.Context, p Paint, scale float64) {
+	if p.Fill != nil {
+		dc.SetColor(p.Fill)
+		dc.FillPreserve()
+	}
+	if p.Stroke != nil && p.StrokeWidth > 0 {
+		dc.SetColor(p.Stroke)
+		dc.SetLineWidth(float64(p.StrokeWidth) * scale)
+		dc.StrokePreserve()
+	}
+	dc.ClearPath()
+}
+
+// textSize 文字的逻辑字号
+func textSize(t *Text) float32 {
+	if t.Size > 0 {
+		return t.Size
+	}
+	return theme.DefaultTheme().Size(theme.SizeNameText)
+}
+
+func (r *Rasterizer) face(size float64, style fyne.TextStyle) (font.Face, error) {
+	k := faceKey{size, style}
+	if f, ok := r.faces[k]; ok {
+		return f, nil
+	}
+
+	var face font.Face
+	var err error
+	if r.Face != nil {
+		face, err = r.Face(size, style)
+	} else {
+		face, err = r.themeFace(size, style)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if r.faces == nil {
+		r.faces = make(map[faceKey]font.Face)
+	}
+	r.faces[k] = face
+	return face, nil
+}
+
+// themeFace 从 Fyne 默认主题的字体资源创建字体
+func (r *Rasterizer) themeFace(size float64, style fyne.TextStyle) (font.Face, error) {
+	f, ok := r.fonts[style]
+	if !ok {
+		var err error
+		f, err = opentype.Parse(theme.DefaultTheme().Font(style).Content())
+		if err != nil {
+			return nil, err
+		}
+		if r.fonts == nil {
+			r.fonts = make(map[fyne.TextStyle]*opentype.Font)
+		}
+		r.fonts[style] = f
+	}
+	return opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingNone})
+}
@@ -0,0 +1,133 @@
+package vector
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+
+	"2025-12-18-ggAndPng/tools/raster"
+)
+
+var (
+	red  = color.NRGBA{R: 255, A: 255}
+	blue = color.NRGBA{B: 255, A: 255}
+)
+
+func nrgba(img image.Image, x, y int) color.NRGBA {
+	return color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+}
+
+// toggleDrawing 开关控件的简化外观：圆角轨道 + 圆形滑块 + 对勾
+func toggleDrawing() *Drawing {
+	return NewDrawing(fyne.NewSize(60, 30)).Add(
+		&RoundRect{Size: fyne.NewSize(60, 30), Radius: 15, Paint: Paint{Fill: red}},
+		&Circle{Center: fyne.NewPos(45, 15), Radius: 12, Paint: Paint{Fill: blue}},
+		(&Path{Paint: Paint{Stroke: color.White, StrokeWidth: 2}}).MoveTo(8, 15).LineTo(12, 19).LineTo(20, 11),
+	)
+}
+
+func TestRasterizeShapes(t *testing.T) {
+	var r Rasterizer
+	img := r.Rasterize(toggleDrawing(), 1)
+	if img.Bounds().Dx() != 60 || img.Bounds().Dy() != 30 {
+		t.Fatalf("尺寸 = %v", img.Bounds().Size())
+	}
+
+	tests := []struct {
+		name string
+		x, y int
+		want color.NRGBA
+	}{
+		{"轨道填充", 25, 15, red},
+		{"滑块覆盖轨道", 45, 15, blue},
+		{"圆角外为透明", 0, 0, color.NRGBA{}},
+		{"对勾描边", 15, 15, color.NRGBA{255, 255, 255, 255}},
+	}
+	for _, tt := range tests {
+		if got := nrgba(img, tt.x, tt.y); got != tt.want {
+			t.Errorf("%s: (%d,%d) = %v, 期望 %v", tt.name, tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestRasterizeScale(t *testing.T) {
+	var r Rasterizer
+	d := toggleDrawing()
+
+	img := r.Rasterize(d, 2)
+	if img.Bounds().Dx() != 120 || img.Bounds().Dy() != 60 {
+		t.Fatalf("2 倍缩放尺寸 = %v", img.Bounds().Size())
+	}
+	if got := nrgba(img, 90, 30); got != blue {
+		t.Errorf("2 倍缩放时滑块中心 = %v", got)
+	}
+
+	// 超过尺寸上限时等比缩小
+	r.Limits = raster.Limits{MaxWidth: 30}
+	if img := r.Rasterize(d, 2); img.Bounds().Dx() != 30 || img.Bounds().Dy() != 15 {
+		t.Errorf("受限后尺寸 = %v", img.Bounds().Size())
+	}
+}
+
+func TestRasterizeText(t *testing.T) {
+	var sizes []float64
+	r := Rasterizer{Face: func(size float64, _ fyne.TextStyle) (font.Face, error) {
+		sizes = append(sizes, size)
+		return basicfont.Face7x13, nil
+	}}
+	d := NewDrawing(fyne.NewSize(40, 20)).Add(
+		&Text{Pos: fyne.NewPos(2, 2), Text: "ON", Size: 12, Color: color.Black},
+		&Text{Pos: fyne.NewPos(20, 2), Text: "ON", Size: 12, Color: color.Black},
+	)
+
+	img := r.Rasterize(d, 2)
+	ink := 0
+	for y := 0; y < img.Bounds().Dy(); y++ {
+		for x := 0; x < img.Bounds().Dx(); x++ {
+			if img.RGBAAt(x, y).A > 0 {
+				ink++
+			}
+		}
+	}
+	if ink == 0 {
+		t.Error("文字应绘制出像素")
+	}
+	// 同一字号只创建一次字体，字号按缩放换算为像素
+	if len(sizes) != 1 || sizes[0] != 24 {
+		t.Errorf("字体创建参数 = %v, 期望 [24]", sizes)
+	}
+}
+
+func TestRasterizeThemeFont(t *testing.T) {
+	var r Rasterizer
+	d := NewDrawing(fyne.NewSize(80, 24)).Add(&Text{Text: "Switch", Color: color.Black})
+	img := r.Rasterize(d, 1)
+	if img.RGBAAt(0, 0).A != 0 {
+		t.Error("文字框左上角不应有像素")
+	}
+	found := false
+	for x := 0; x < 40 && !found; x++ {
+		for y := 0; y < 24; y++ {
+			if img.RGBAAt(x, y).A > 128 {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		t.Error("默认主题字体应绘制出文字")
+	}
+}
+
+func TestDrawReusesBuffer(t *testing.T) {
+	var r Rasterizer
+	buf := image.NewRGBA(image.Rect(0, 0, 60, 30))
+	r.Draw(buf, toggleDrawing(), 1)
+	if got := nrgba(buf, 45, 15); got != blue {
+		t.Errorf("Draw 到已有图像失败: %v", got)
+	}
+}
@@ -0,0 +1,150 @@
+// vector.go
+package vector
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+)
+
+// Paint 图形的填充和描边，颜色为 nil 时不绘制该部分
+// 描边以轮廓线为中心，与 Fyne 的 canvas.Rectangle / canvas.Circle 一致
+type Paint struct {
+	Fill        color.Color
+	Stroke      color.Color
+	StrokeWidth float32
+}
+
+// Primitive 矢量图元：RoundRect、Circle、Path 或 Text
+// 坐标和尺寸都是逻辑单位（与 fyne.Size 相同），光栅化时再乘以缩放倍数
+type Primitive interface {
+	primitive()
+}
+
+// RoundRect 圆角矩形，Radius 为 0 时是直角矩形
+type RoundRect struct {
+	Pos    fyne.Position
+	Size   fyne.Size
+	Radius float32
+	Paint
+}
+
+// Circle 圆形
+type Circle struct {
+	Center fyne.Position
+	Radius float32
+	Paint
+}
+
+// PathOp 路径命令
+type PathOp uint8
+
+const (
+	OpMoveTo PathOp = iota
+	OpLineTo
+	OpQuadTo  // 二次贝塞尔曲线，Pts[0] 为控制点
+	OpCubicTo // 三次贝塞尔曲线，Pts[0]、Pts[1] 为控制点
+	OpClose
+)
+
+// PathSegment 一条路径命令，终点为最后一个用到的点
+type PathSegment struct {
+	Op  PathOp
+	Pts [3]fyne.Position
+}
+
+// Path 由直线和贝塞尔曲线组成的路径，例如复选框的对勾
+type Path struct {
+	Segments []PathSegment
+	Paint
+}
+
+// MoveTo 开始新的子路径，返回自身以便链式调用
+func (p *Path) MoveTo(x, y float32) *Path {
+	return p.add(OpMoveTo, fyne.NewPos(x, y))
+}
+
+// LineTo 画直线到 (x, y)
+func (p *Path) LineTo(x, y float32) *Path {
+	return p.add(OpLineTo, fyne.NewPos(x, y))
+}
+
+// QuadTo 以 (cx, cy) 为控制点画二次贝塞尔曲线到 (x, y)
+func (p *Path) QuadTo(cx, cy, x, y float32) *Path {
+	return p.add(OpQuadTo, fyne.NewPos(cx, cy), fyne.NewPos(x, y))
+}
+
+// CubicTo 以 (c1x, c1y)、(c2x, c2y) 为控制点画三次贝塞尔曲线到 (x, y)
+func (p *Path) CubicTo(c1x, c1y, c2x, c2y, x, y float32) *Path {
+	return p.add(OpCubicTo, fyne.NewPos(c1x, c1y), fyne.NewPos(c2x, c2y), fyne.NewPos(x, y))
+}
+
+// Close 闭合当前子路径
+func (p *Path) Close() *Path {
+	return p.add(OpClose)
+}
+
+func (p *Path) add(op PathOp, pts ...fyne.Position) *Path {
+	s := PathSegment{Op: op}
+	copy(s.Pts[:], pts)
+	p.Segments = append(p.Segments, s)
+	return p
+}
+
+// Bounds 返回路径所有点（含控制点）的外接矩形，不含描边宽度
+func (p *Path) Bounds() (fyne.Position, fyne.Size) {
+	first := true
+	var lo, hi fyne.Position
+	for _, s := range p.Segments {
+		n := 0
+		switch s.Op {
+		case OpMoveTo, OpLineTo:
+			n = 1
+		case OpQuadTo:
+			n = 2
+		case OpCubicTo:
+			n = 3
+		}
+		for _, pt := range s.Pts[:n] {
+			if first {
+				lo, hi, first = pt, pt, false
+				continue
+			}
+			lo = fyne.NewPos(min(lo.X, pt.X), min(lo.Y, pt.Y))
+			hi = fyne.NewPos(max(hi.X, pt.X), max(hi.Y, pt.Y))
+		}
+	}
+	return lo, fyne.NewSize(hi.X-lo.X, hi.Y-lo.Y)
+}
+
+// Text 单行文字，Pos 为文字框的左上角
+type Text struct {
+	Pos   fyne.Position
+	Text  string
+	Size  float32 // 字号，0 表示使用 Fyne 主题的默认字号
+	Color color.Color
+	Style fyne.TextStyle
+}
+
+func (*RoundRect) primitive() {}
+func (*Circle) primitive()    {}
+func (*Path) primitive()      {}
+func (*Text) primitive()      {}
+
+// Drawing 控件外观的矢量描述：按顺序绘制的图元列表
+// 控件只需描述一次外观，再按 Backend 选择由 gg 光栅化或转换为 Fyne canvas 对象
+type Drawing struct {
+	Size  fyne.Size
+	Items []Primitive
+}
+
+// NewDrawing 创建逻辑尺寸为 size 的空白图
+func NewDrawing(size fyne.Size) *Drawing {
+	return &Drawing{Size: size}
+}
+
+// Add 追加图元，返回自身以便链式调用
+func (d *Drawing) Add(items ...Primitive) *Drawing {
+	d.Items = append(d.Items, items...)
+	return d
+}
@@ -0,0 +1,52 @@
+package vector
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+)
+
+func TestPathBuilder(t *testing.T) {
+	p := (&Path{}).MoveTo(2, 6).LineTo(5, 9).QuadTo(8, 0, 10, 3).CubicTo(1, 1, 2, 2, 4, 4).Close()
+
+	ops := []PathOp{OpMoveTo, OpLineTo, OpQuadTo, OpCubicTo, OpClose}
+	if len(p.Segments) != len(ops) {
+		t.Fatalf("命令数 = %d, 期望 %d", len(p.Segments), len(ops))
+	}
+	for i, op := range ops {
+		if p.Segments[i].Op != op {
+			t.Errorf("第 %d 条命令 = %v, 期望 %v", i, p.Segments[i].Op, op)
+		}
+	}
+	if got := p.Segments[2].Pts[1]; got != fyne.NewPos(10, 3) {
+		t.Errorf("QuadTo 终点 = %v", got)
+	}
+}
+
+func TestPathBounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     *Path
+		wantPos  fyne.Position
+		wantSize fyne.Size
+	}{
+		{"空路径", &Path{}, fyne.Position{}, fyne.Size{}},
+		{"对勾", (&Path{}).MoveTo(4, 12).LineTo(9, 17).LineTo(20, 6), fyne.NewPos(4, 6), fyne.NewSize(16, 11)},
+		{"控制点计入外接矩形", (&Path{}).MoveTo(0, 10).QuadTo(5, -4, 10, 10).Close(), fyne.NewPos(0, -4), fyne.NewSize(10, 14)},
+	}
+	for _, tt := range tests {
+		pos, size := tt.path.Bounds()
+		if pos != tt.wantPos || size != tt.wantSize {
+			t.Errorf("%s: Bounds() = %v %v, 期望 %v %v", tt.name, pos, size, tt.wantPos, tt.wantSize)
+		}
+	}
+}
+
+func TestDrawingAdd(t *testing.T) {
+	d := NewDrawing(fyne.NewSize(40, 20)).
+		Add(&RoundRect{Size: fyne.NewSize(40, 20), Radius: 10}).
+		Add(&Circle{Center: fyne.NewPos(10, 10), Radius: 8}, &Text{Text: "开"})
+	if len(d.Items) != 3 || d.Size != fyne.NewSize(40, 20) {
+		t.Errorf("Drawing 内容错误: %+v", d)
+	}
+}